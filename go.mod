@@ -4,6 +4,7 @@ go 1.25.0
 
 require (
 	github.com/edgexfoundry/device-sdk-go/v4 v4.0.1
+	github.com/edgexfoundry/go-mod-bootstrap/v4 v4.0.4
 	github.com/edgexfoundry/go-mod-core-contracts/v4 v4.0.2
 	github.com/go-playground/validator/v10 v10.30.2
 	github.com/gopcua/opcua v0.8.0
@@ -20,7 +21,6 @@ require (
 	github.com/cespare/xxhash/v2 v2.3.0 // indirect
 	github.com/davecgh/go-spew v1.1.2-0.20180830191138-d8f796af33cc // indirect
 	github.com/eclipse/paho.mqtt.golang v1.5.1 // indirect
-	github.com/edgexfoundry/go-mod-bootstrap/v4 v4.0.4 // indirect
 	github.com/edgexfoundry/go-mod-configuration/v4 v4.0.2 // indirect
 	github.com/edgexfoundry/go-mod-messaging/v4 v4.0.2 // indirect
 	github.com/edgexfoundry/go-mod-registry/v4 v4.0.1 // indirect
@@ -8,11 +8,15 @@ package driver
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
+	"time"
 
+	"github.com/edgexfoundry/device-opcua-go/internal/server"
 	"github.com/edgexfoundry/go-mod-core-contracts/v4/dtos/common"
 	"github.com/go-playground/validator/v10"
+	"github.com/gopcua/opcua/ua"
 	"github.com/labstack/echo/v4"
 	"github.com/spf13/cast"
 )
@@ -74,3 +78,103 @@ func handleMethodCall(e echo.Context) error {
 	baseResponse := common.NewBaseResponse(id, cast.ToString(response), http.StatusOK)
 	return e.JSON(http.StatusOK, baseResponse)
 }
+
+type ConditionRequest struct {
+	DeviceName   string `json:"device" validate:"required"`
+	MethodName   string `json:"method" validate:"required,oneof=Acknowledge Confirm AddComment TimedShelve OneShotShelve Unshelve Enable Disable"`
+	ConditionID  string `json:"conditionId" validate:"required"`
+	EventID      string `json:"eventId,omitempty" validate:"required_if=MethodName Acknowledge,required_if=MethodName Confirm,required_if=MethodName AddComment,omitempty,base64"`
+	Comment      string `json:"comment,omitempty"`
+	ShelvingTime string `json:"shelvingTime,omitempty" validate:"required_if=MethodName TimedShelve"`
+}
+
+func (r *ConditionRequest) validate() error {
+	if validate == nil {
+		validate = validator.New()
+	}
+
+	return validate.Struct(r)
+}
+
+func handleConditionCall(e echo.Context) error {
+	w := e.Response()
+	r := e.Request()
+	w.Header().Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+	id := r.Header.Get("X-Correlation-ID")
+
+	if r.Body == nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "request body required")
+	}
+	defer r.Body.Close()
+
+	var req ConditionRequest
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		driver.sdk.LoggingClient().Errorf("invalid request: %v", err)
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid request")
+	}
+
+	if err := req.validate(); err != nil {
+		msg := fmt.Sprintf("invalid request: %v", err)
+		driver.sdk.LoggingClient().Error(msg)
+		return echo.NewHTTPError(http.StatusBadRequest, msg)
+	}
+
+	var shelvingTime time.Duration
+	if req.ShelvingTime != "" {
+		if shelvingTime, err = time.ParseDuration(req.ShelvingTime); err != nil {
+			msg := fmt.Sprintf("invalid request: shelving time: %v", err)
+			driver.sdk.LoggingClient().Error(msg)
+			return echo.NewHTTPError(http.StatusBadRequest, msg)
+		}
+	}
+
+	// get device from server map
+	s, ok := driver.serverMap[req.DeviceName]
+	if !ok || s == nil {
+		return echo.NewHTTPError(http.StatusNotFound, fmt.Sprintf("device %s not found", req.DeviceName))
+	}
+
+	err = s.ProcessConditionCall(server.ConditionCall{
+		Method:       req.MethodName,
+		ConditionID:  req.ConditionID,
+		EventID:      req.EventID,
+		Comment:      req.Comment,
+		ShelvingTime: shelvingTime,
+	})
+	if err != nil {
+		driver.sdk.LoggingClient().Errorf(err.Error())
+		code, msg := conditionCallError(err)
+		return echo.NewHTTPError(code, msg)
+	}
+
+	baseResponse := common.NewBaseResponse(id, "", http.StatusOK)
+	return e.JSON(http.StatusOK, baseResponse)
+}
+
+// conditionCallError maps the status code of a failed condition call to an HTTP error
+func conditionCallError(err error) (int, string) {
+	var status ua.StatusCode
+	if !errors.As(err, &status) {
+		return http.StatusInternalServerError, "error interacting with device"
+	}
+
+	code := http.StatusBadGateway
+	switch status {
+	case ua.StatusBadInvalidArgument, ua.StatusBadArgumentsMissing, ua.StatusBadTypeMismatch,
+		ua.StatusBadShelvingTimeOutOfRange, ua.StatusBadMethodInvalid:
+		code = http.StatusBadRequest
+	case ua.StatusBadUserAccessDenied:
+		code = http.StatusForbidden
+	case ua.StatusBadEventIDUnknown, ua.StatusBadNodeIDUnknown, ua.StatusBadNodeIDInvalid, ua.StatusBadNoMatch:
+		code = http.StatusNotFound
+	case ua.StatusBadConditionBranchAlreadyAcked, ua.StatusBadConditionBranchAlreadyConfirmed,
+		ua.StatusBadConditionAlreadyEnabled, ua.StatusBadConditionAlreadyDisabled, ua.StatusBadConditionDisabled,
+		ua.StatusBadConditionAlreadyShelved, ua.StatusBadConditionNotShelved, ua.StatusBadStateNotActive:
+		code = http.StatusConflict
+	case ua.StatusBadNotSupported:
+		code = http.StatusNotImplemented
+	}
+	return code, status.Error()
+}
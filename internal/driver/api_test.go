@@ -8,6 +8,8 @@ package driver
 
 import (
 	"bytes"
+	"errors"
+	"fmt"
 	"io"
 	"net/http"
 	"testing"
@@ -15,6 +17,7 @@ import (
 	"github.com/edgexfoundry/device-opcua-go/internal/server"
 	"github.com/edgexfoundry/device-opcua-go/internal/test"
 	"github.com/edgexfoundry/go-mod-core-contracts/v4/models"
+	"github.com/gopcua/opcua/ua"
 	"github.com/labstack/echo/v4"
 )
 
@@ -104,7 +107,7 @@ func Test_handleMethodCall(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			d, dsMock := newMockDriver(t)
 			if tt.deviceName != "" {
-				d.serverMap[tt.deviceName] = server.NewServer(tt.deviceName, dsMock)
+				d.serverMap[tt.deviceName] = server.NewServer(tt.deviceName, dsMock, nil)
 				dsMock.On("GetDeviceByName", tt.deviceName).Return(models.Device{Name: tt.deviceName}, nil)
 				dsMock.On("DeviceResource", tt.deviceName, tt.methodName).Return(tt.resource, true)
 			}
@@ -118,3 +121,141 @@ func Test_handleMethodCall(t *testing.T) {
 		})
 	}
 }
+
+func TestConditionRequest_validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		req     ConditionRequest
+		wantErr bool
+	}{
+		{
+			name: "OK - acknowledge",
+			req:  ConditionRequest{DeviceName: "Device", MethodName: "Acknowledge", ConditionID: "ns=2;s=Alarm", EventID: "AQI=", Comment: "done"},
+		},
+		{
+			name: "OK - timed shelve",
+			req:  ConditionRequest{DeviceName: "Device", MethodName: "TimedShelve", ConditionID: "ns=2;s=Alarm", ShelvingTime: "1h"},
+		},
+		{
+			name: "OK - enable",
+			req:  ConditionRequest{DeviceName: "Device", MethodName: "Enable", ConditionID: "ns=2;s=Alarm"},
+		},
+		{
+			name:    "NOK - unknown method",
+			req:     ConditionRequest{DeviceName: "Device", MethodName: "Silence", ConditionID: "ns=2;s=Alarm"},
+			wantErr: true,
+		},
+		{
+			name:    "NOK - missing condition",
+			req:     ConditionRequest{DeviceName: "Device", MethodName: "Enable"},
+			wantErr: true,
+		},
+		{
+			name:    "NOK - confirm without event id",
+			req:     ConditionRequest{DeviceName: "Device", MethodName: "Confirm", ConditionID: "ns=2;s=Alarm"},
+			wantErr: true,
+		},
+		{
+			name:    "NOK - event id not base64",
+			req:     ConditionRequest{DeviceName: "Device", MethodName: "AddComment", ConditionID: "ns=2;s=Alarm", EventID: "#"},
+			wantErr: true,
+		},
+		{
+			name:    "NOK - timed shelve without shelving time",
+			req:     ConditionRequest{DeviceName: "Device", MethodName: "TimedShelve", ConditionID: "ns=2;s=Alarm"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.req.validate(); (err != nil) != tt.wantErr {
+				t.Errorf("ConditionRequest.validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_handleConditionCall(t *testing.T) {
+	tests := []struct {
+		name     string
+		body     io.Reader
+		device   *models.Device
+		wantCode int
+	}{
+		{
+			name:     "NOK - no body",
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name:     "NOK - invalid request",
+			body:     bytes.NewBufferString(`{"device":"test","method":"Enable"}`),
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name:     "NOK - invalid shelving time",
+			body:     bytes.NewBufferString(`{"device":"test","method":"TimedShelve","conditionId":"ns=2;s=Alarm","shelvingTime":"soon"}`),
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name:     "NOK - device not found",
+			body:     bytes.NewBufferString(`{"device":"test","method":"Enable","conditionId":"ns=2;s=Alarm"}`),
+			wantCode: http.StatusNotFound,
+		},
+		{
+			name:     "NOK - invalid condition id",
+			body:     bytes.NewBufferString(`{"device":"test","method":"Enable","conditionId":"ns=two;s=Alarm"}`),
+			device:   &models.Device{Name: "test", AdminState: models.Unlocked, OperatingState: models.Up},
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name:     "NOK - device locked",
+			body:     bytes.NewBufferString(`{"device":"test","method":"Enable","conditionId":"ns=2;s=Alarm"}`),
+			device:   &models.Device{Name: "test", AdminState: models.Locked},
+			wantCode: http.StatusInternalServerError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d, dsMock := newMockDriver(t)
+			if tt.device != nil {
+				d.serverMap[tt.device.Name] = server.NewServer(tt.device.Name, dsMock, nil)
+				dsMock.On("GetDeviceByName", tt.device.Name).Return(*tt.device, nil)
+			}
+			request, _ := http.NewRequest(http.MethodPost, "", tt.body)
+			c := echo.New().NewContext(request, new(test.ResponseWriterMock))
+			err := handleConditionCall(c)
+
+			var httpErr *echo.HTTPError
+			if !errors.As(err, &httpErr) {
+				t.Fatalf("wanted HTTP error, received: %v", err)
+			}
+			if httpErr.Code != tt.wantCode {
+				t.Errorf("handleConditionCall() code = %d, want %d", httpErr.Code, tt.wantCode)
+			}
+		})
+	}
+}
+
+func Test_conditionCallError(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		wantCode int
+	}{
+		{name: "event unknown", err: fmt.Errorf("failed: %w", ua.StatusBadEventIDUnknown), wantCode: http.StatusNotFound},
+		{name: "condition unknown", err: fmt.Errorf("failed: %w", ua.StatusBadNodeIDUnknown), wantCode: http.StatusNotFound},
+		{name: "already acked", err: fmt.Errorf("failed: %w", ua.StatusBadConditionBranchAlreadyAcked), wantCode: http.StatusConflict},
+		{name: "not shelved", err: fmt.Errorf("failed: %w", ua.StatusBadConditionNotShelved), wantCode: http.StatusConflict},
+		{name: "shelving time", err: fmt.Errorf("failed: %w", ua.StatusBadShelvingTimeOutOfRange), wantCode: http.StatusBadRequest},
+		{name: "access denied", err: fmt.Errorf("failed: %w", ua.StatusBadUserAccessDenied), wantCode: http.StatusForbidden},
+		{name: "other status", err: fmt.Errorf("failed: %w", ua.StatusBadTimeout), wantCode: http.StatusBadGateway},
+		{name: "no status", err: fmt.Errorf("device is locked"), wantCode: http.StatusInternalServerError},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if code, _ := conditionCallError(tt.err); code != tt.wantCode {
+				t.Errorf("conditionCallError() code = %d, want %d", code, tt.wantCode)
+			}
+		})
+	}
+}
@@ -9,6 +9,7 @@
 package driver
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"sync"
@@ -25,9 +26,13 @@ var driver *Driver
 
 // Driver struct
 type Driver struct {
-	mu        sync.Mutex
-	serverMap map[string]*server.Server
-	sdk       interfaces.DeviceServiceSDK
+	mu            sync.Mutex
+	serverMap     map[string]*server.Server
+	serviceConfig *server.ServiceConfig
+	monitor       *server.CertificateMonitor
+	reverse       *server.ReverseListener
+	stopMonitor   context.CancelFunc
+	sdk           interfaces.DeviceServiceSDK
 }
 
 // NewProtocolDriver returns a new protocol driver object
@@ -42,10 +47,33 @@ func NewProtocolDriver() interfaces.ProtocolDriver {
 func (d *Driver) Initialize(sdk interfaces.DeviceServiceSDK) error {
 	d.sdk = sdk
 
+	d.serviceConfig = &server.ServiceConfig{}
+	if err := d.sdk.LoadCustomConfig(d.serviceConfig, server.CustomConfigSectionName); err != nil {
+		return fmt.Errorf("unable to load '%s' custom configuration: %v", server.CustomConfigSectionName, err)
+	}
+
+	if d.serviceConfig.OPCUA.GeneratesCertificate() {
+		if _, err := server.EnsureApplicationCertificate(d.serviceConfig.OPCUA, d.sdk.LoggingClient()); err != nil {
+			return err
+		}
+	}
+
 	// Define custom API endpoints
 	if err := d.sdk.AddCustomRoute("/api/v3/call", interfaces.Authenticated, handleMethodCall, http.MethodPost); err != nil {
 		return fmt.Errorf("unable to add custom route to device service: %v", err)
 	}
+	if err := d.sdk.AddCustomRoute("/api/v3/condition", interfaces.Authenticated, handleConditionCall, http.MethodPost); err != nil {
+		return fmt.Errorf("unable to add custom route to device service: %v", err)
+	}
+
+	// Servers configured for reverse connect open their connections to the service
+	if address := d.serviceConfig.OPCUA.ReverseConnectAddress; address != "" {
+		reverse, err := server.StartReverseListener(address, d.sdk.LoggingClient())
+		if err != nil {
+			return err
+		}
+		d.reverse = reverse
+	}
 
 	d.mu.Lock()
 	d.serverMap = make(map[string]*server.Server)
@@ -58,19 +86,39 @@ func (d *Driver) Initialize(sdk interfaces.DeviceServiceSDK) error {
 		}
 	}
 
+	monitor, err := server.NewCertificateMonitor(d.serviceConfig.OPCUA, d.sdk.LoggingClient(), d.servers)
+	if err != nil {
+		return err
+	}
+	d.monitor = monitor
+
 	return nil
 }
 
+// servers returns the servers of the devices currently managed by the driver
+func (d *Driver) servers() []*server.Server {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	servers := make([]*server.Server, 0, len(d.serverMap))
+	for _, s := range d.serverMap {
+		if s != nil {
+			servers = append(servers, s)
+		}
+	}
+	return servers
+}
+
 // AddDevice is a callback function that is invoked
 // when a new Device associated with this Device Service is added
 func (d *Driver) AddDevice(deviceName string, protocols map[string]models.ProtocolProperties, adminState models.AdminState) error {
 	d.sdk.LoggingClient().Debugf("Device %s is added. Starting subscription mechanism...", deviceName)
 	d.mu.Lock()
-	s := server.NewServer(deviceName, d.sdk)
+	s := server.NewServer(deviceName, d.sdk, d.serviceConfig)
 	d.serverMap[deviceName] = s
 	d.mu.Unlock()
 
-	go s.StartSubscriptionListener() // nolint:errcheck
+	s.Start()
 	return nil
 }
 
@@ -79,8 +127,7 @@ func (d *Driver) AddDevice(deviceName string, protocols map[string]models.Protoc
 func (d *Driver) UpdateDevice(deviceName string, protocols map[string]models.ProtocolProperties, adminState models.AdminState) error {
 	d.sdk.LoggingClient().Debugf("Device %s is updated. Restarting subscription mechanism...", deviceName)
 	if s, ok := d.serverMap[deviceName]; ok {
-		s.Cleanup(true)
-		go s.StartSubscriptionListener() // nolint:errcheck
+		s.Restart()
 		return nil
 	}
 
@@ -109,14 +156,36 @@ func (d *Driver) ValidateDevice(device models.Device) error {
 		return fmt.Errorf("error reading protocol properties, %v", err)
 	}
 
-	return server.Validate(cfg)
+	if err := server.Validate(cfg); err != nil {
+		return err
+	}
+
+	if cfg.CertFile == "" && cfg.RequiresCertificate() && (d.serviceConfig == nil || !d.serviceConfig.OPCUA.GeneratesCertificate()) {
+		return fmt.Errorf("CertFile and KeyFile are required for security policy %s and mode %s, unless %s.CertificateDir or PKIDir is configured",
+			cfg.Policy, cfg.Mode, server.CustomConfigSectionName)
+	}
+
+	if cfg.ReverseConnect && (d.serviceConfig == nil || d.serviceConfig.OPCUA.ReverseConnectAddress == "") {
+		return fmt.Errorf("ReverseConnect requires %s.ReverseConnectAddress to be configured", server.CustomConfigSectionName)
+	}
+
+	return nil
 }
 
 func (d *Driver) Discover() error {
 	return fmt.Errorf("driver's Discover function isn't implemented")
 }
 
+// Start runs the application certificate monitor once the service is initialized
 func (d *Driver) Start() error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.monitor != nil && d.stopMonitor == nil {
+		ctx, cancel := context.WithCancel(context.Background())
+		d.stopMonitor = cancel
+		go d.monitor.Run(ctx)
+	}
 	return nil
 }
 
@@ -127,6 +196,16 @@ func (d *Driver) Start() error {
 func (d *Driver) Stop(force bool) error {
 	d.mu.Lock()
 	defer d.mu.Unlock()
+	if d.stopMonitor != nil {
+		d.stopMonitor()
+		d.stopMonitor = nil
+	}
+	if d.reverse != nil {
+		if err := d.reverse.Close(); err != nil {
+			d.sdk.LoggingClient().Warnf("failed to close reverse connect listener: %v", err)
+		}
+		d.reverse = nil
+	}
 	d.serverMap = nil
 	d.sdk = nil
 	return nil
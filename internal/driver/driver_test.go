@@ -178,11 +178,17 @@ func TestDriver_HandleWriteCommands(t *testing.T) {
 
 func TestDriver_Initialize(t *testing.T) {
 	tests := []struct {
-		name    string
-		devices []models.Device
-		err     error
-		wantErr bool
+		name      string
+		devices   []models.Device
+		configErr error
+		err       error
+		wantErr   bool
 	}{
+		{
+			name:      "NOK - error loading custom configuration",
+			configErr: fmt.Errorf("error"),
+			wantErr:   true,
+		},
 		{
 			name:    "NOK - error adding route",
 			err:     fmt.Errorf("error"),
@@ -196,8 +202,14 @@ func TestDriver_Initialize(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			d, dsMock := newMockDriver(t)
-			dsMock.On("AddCustomRoute", "/api/v3/call", mock.Anything, mock.AnythingOfType("func(echo.Context) error"), http.MethodPost).Return(tt.err)
-			if tt.err == nil {
+			dsMock.On("LoadCustomConfig", mock.AnythingOfType("*server.ServiceConfig"), server.CustomConfigSectionName).Return(tt.configErr)
+			if tt.configErr == nil {
+				dsMock.On("AddCustomRoute", "/api/v3/call", mock.Anything, mock.AnythingOfType("func(echo.Context) error"), http.MethodPost).Return(tt.err)
+			}
+			if tt.configErr == nil && tt.err == nil {
+				dsMock.On("AddCustomRoute", "/api/v3/condition", mock.Anything, mock.AnythingOfType("func(echo.Context) error"), http.MethodPost).Return(nil)
+			}
+			if tt.configErr == nil && tt.err == nil {
 				dsMock.On("Devices").Return(tt.devices)
 			}
 			if err := d.Initialize(dsMock); (err != nil) != tt.wantErr {
@@ -220,6 +232,26 @@ func TestDriver_ValidateDevice(t *testing.T) {
 			}}},
 			wantErr: true,
 		},
+		{
+			name: "NOK - secure channel without application certificate",
+			device: models.Device{Protocols: map[string]models.ProtocolProperties{"opcua": {
+				"Endpoint":  test.Address,
+				"Policy":    "Basic256Sha256",
+				"Mode":      "SignAndEncrypt",
+				"Resources": []string{"A", "B", "C"},
+			}}},
+			wantErr: true,
+		},
+		{
+			name: "NOK - reverse connect not enabled",
+			device: models.Device{Protocols: map[string]models.ProtocolProperties{"opcua": {
+				"Endpoint":       test.Address,
+				"Policy":         "None",
+				"Mode":           "None",
+				"ReverseConnect": true,
+			}}},
+			wantErr: true,
+		},
 		{
 			name: "OK - valid device",
 			device: models.Device{Protocols: map[string]models.ProtocolProperties{"opcua": {
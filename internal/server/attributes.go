@@ -0,0 +1,140 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2025 Schneider Electric
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package server
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	sdkModel "github.com/edgexfoundry/device-sdk-go/v4/pkg/models"
+	"github.com/gopcua/opcua/id"
+	"github.com/gopcua/opcua/ua"
+	"github.com/spf13/cast"
+)
+
+// analogProperties are the properties of analog items which can be read as the attributeId of a resource,
+// although they are the values of child variables rather than attributes of the node
+var analogProperties = []string{"EngineeringUnits", "EURange", "InstrumentRange"}
+
+// readAttribute returns the attribute of the node read for the resource, given by its attributeId
+// attribute as a name such as DisplayName or as a number, the Value by default. Analog item
+// properties are returned as the property name, with the Value attribute of the property node.
+func readAttribute(attrs map[string]any) (ua.AttributeID, string, error) {
+	v, ok := attrs[ATTRIBUTEID]
+	if !ok {
+		return ua.AttributeIDValue, "", nil
+	}
+
+	name := cast.ToString(v)
+	for _, property := range analogProperties {
+		if strings.EqualFold(name, property) {
+			return ua.AttributeIDValue, property, nil
+		}
+	}
+	for attr := ua.AttributeIDNodeID; attr <= ua.AttributeIDAccessLevelEx; attr++ {
+		if strings.EqualFold(name, strings.TrimPrefix(attr.String(), "AttributeID")) {
+			return attr, "", nil
+		}
+	}
+	if n, err := cast.ToUint32E(v); err == nil && n >= uint32(ua.AttributeIDNodeID) && n <= uint32(ua.AttributeIDAccessLevelEx) {
+		return ua.AttributeID(n), "", nil
+	}
+	return ua.AttributeIDInvalid, "", fmt.Errorf("unknown %s %v", ATTRIBUTEID, v)
+}
+
+// resolveProperties returns the requests with the analog item properties replaced by the nodes
+// of the properties, whose Value is read
+func (s *Server) resolveProperties(ctx context.Context, client *Client, reqs []sdkModel.CommandRequest) ([]sdkModel.CommandRequest, error) {
+	var paths []*ua.BrowsePath
+	var indexes []int
+	for i, req := range reqs {
+		_, property, err := readAttribute(req.Attributes)
+		if err != nil || property == "" {
+			continue
+		}
+		node, err := getNodeID(req.Attributes, NODE)
+		if err != nil {
+			return nil, err
+		}
+		paths = append(paths, &ua.BrowsePath{
+			StartingNode: node,
+			RelativePath: &ua.RelativePath{Elements: []*ua.RelativePathElement{{
+				ReferenceTypeID: ua.NewNumericNodeID(0, id.HasProperty),
+				TargetName:      &ua.QualifiedName{Name: property},
+			}}},
+		})
+		indexes = append(indexes, i)
+	}
+	if len(paths) == 0 {
+		return reqs, nil
+	}
+
+	resolved := slices.Clone(reqs)
+	err := client.Send(ctx, &ua.TranslateBrowsePathsToNodeIDsRequest{BrowsePaths: paths}, func(v ua.Response) error {
+		res, ok := v.(*ua.TranslateBrowsePathsToNodeIDsResponse)
+		if !ok {
+			return fmt.Errorf("unexpected response type %T", v)
+		}
+		if len(res.Results) != len(paths) {
+			return fmt.Errorf("unexpected number of results %d", len(res.Results))
+		}
+		for i, result := range res.Results {
+			req := &resolved[indexes[i]]
+			if result.StatusCode != ua.StatusOK {
+				return fmt.Errorf("resource %s: %w", req.DeviceResourceName, result.StatusCode)
+			}
+			if len(result.Targets) == 0 || result.Targets[0].TargetID == nil {
+				return fmt.Errorf("resource %s: %w", req.DeviceResourceName, ua.StatusBadNoMatch)
+			}
+			req.Attributes = maps.Clone(req.Attributes)
+			req.Attributes[NODE] = result.Targets[0].TargetID.NodeID.String()
+			delete(req.Attributes, ATTRIBUTEID)
+		}
+		return nil
+	})
+	return resolved, err
+}
+
+// readingValue converts the values of attributes and structures to the types of readings:
+// node ids are formatted as strings, names and localized texts keep their text, and the
+// engineering units and ranges of analog items are mapped by field
+func readingValue(v any) any {
+	switch v := v.(type) {
+	case *ua.NodeID:
+		return v.String()
+	case *ua.ExpandedNodeID:
+		return v.String()
+	case *ua.LocalizedText:
+		if v == nil {
+			return ""
+		}
+		return v.Text
+	case *ua.QualifiedName:
+		if v == nil {
+			return ""
+		}
+		return v.Name
+	case ua.StatusCode:
+		return v.Error()
+	case *ua.ExtensionObject:
+		switch o := v.Value.(type) {
+		case *ua.EUInformation:
+			return map[string]any{
+				"NamespaceUri": o.NamespaceURI,
+				"UnitId":       o.UnitID,
+				"DisplayName":  readingValue(o.DisplayName),
+				"Description":  readingValue(o.Description),
+			}
+		case *ua.Range:
+			return map[string]any{"Low": o.Low, "High": o.High}
+		}
+	}
+	return v
+}
@@ -0,0 +1,128 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2025 Schneider Electric
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package server
+
+import (
+	"testing"
+
+	"github.com/edgexfoundry/device-opcua-go/internal/test"
+	gopcuaMocks "github.com/edgexfoundry/device-opcua-go/pkg/gopcua/mocks"
+	sdkModel "github.com/edgexfoundry/device-sdk-go/v4/pkg/models"
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/common"
+	"github.com/gopcua/opcua"
+	"github.com/gopcua/opcua/ua"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadAttribute(t *testing.T) {
+	tests := []struct {
+		name         string
+		attrs        map[string]any
+		want         ua.AttributeID
+		wantProperty string
+		wantErr      bool
+	}{
+		{name: "OK - value by default", attrs: map[string]any{}, want: ua.AttributeIDValue},
+		{name: "OK - display name", attrs: map[string]any{ATTRIBUTEID: "DisplayName"}, want: ua.AttributeIDDisplayName},
+		{name: "OK - case insensitive", attrs: map[string]any{ATTRIBUTEID: "accessLevel"}, want: ua.AttributeIDAccessLevel},
+		{name: "OK - spec spelling", attrs: map[string]any{ATTRIBUTEID: "NodeId"}, want: ua.AttributeIDNodeID},
+		{name: "OK - number", attrs: map[string]any{ATTRIBUTEID: 14}, want: ua.AttributeIDDataType},
+		{name: "OK - property", attrs: map[string]any{ATTRIBUTEID: "euRange"}, want: ua.AttributeIDValue, wantProperty: "EURange"},
+		{name: "NOK - unknown name", attrs: map[string]any{ATTRIBUTEID: "Colour"}, wantErr: true},
+		{name: "NOK - number out of range", attrs: map[string]any{ATTRIBUTEID: 99}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, property, err := readAttribute(tt.attrs)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantProperty, property)
+		})
+	}
+}
+
+func TestReadingValue(t *testing.T) {
+	tests := []struct {
+		name  string
+		value any
+		want  any
+	}{
+		{name: "primitive", value: int32(5), want: int32(5)},
+		{name: "node id", value: ua.NewNumericNodeID(0, 11), want: "i=11"},
+		{name: "localized text", value: ua.NewLocalizedText("Boiler temperature"), want: "Boiler temperature"},
+		{name: "qualified name", value: &ua.QualifiedName{NamespaceIndex: 2, Name: "Temperature"}, want: "Temperature"},
+		{name: "range", value: ua.NewExtensionObject(&ua.Range{Low: -10, High: 120}), want: map[string]any{"Low": -10.0, "High": 120.0}},
+		{
+			name: "engineering units",
+			value: ua.NewExtensionObject(&ua.EUInformation{
+				NamespaceURI: "http://www.opcfoundation.org/UA/units/un/cefact",
+				UnitID:       4408652,
+				DisplayName:  ua.NewLocalizedText("°C"),
+			}),
+			want: map[string]any{
+				"NamespaceUri": "http://www.opcfoundation.org/UA/units/un/cefact",
+				"UnitId":       int32(4408652),
+				"DisplayName":  "°C",
+				"Description":  "",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, readingValue(tt.value))
+		})
+	}
+}
+
+func TestServer_ProcessReadCommands_attributes(t *testing.T) {
+	node := ua.NewStringNodeID(2, "Boiler.Temperature")
+	euRange := ua.NewStringNodeID(2, "Boiler.Temperature.EURange")
+	reqs := []sdkModel.CommandRequest{{
+		DeviceResourceName: "Temperature",
+		Attributes:         map[string]any{NODE: node.String()},
+		Type:               common.ValueTypeFloat64,
+	}, {
+		DeviceResourceName: "TemperatureName",
+		Attributes:         map[string]any{NODE: node.String(), ATTRIBUTEID: "DisplayName"},
+		Type:               common.ValueTypeString,
+	}, {
+		DeviceResourceName: "TemperatureRange",
+		Attributes:         map[string]any{NODE: node.String(), ATTRIBUTEID: "EURange"},
+		Type:               common.ValueTypeObject,
+	}}
+
+	s := NewServer("Test", test.NewDSMock(t), nil)
+	s.config = &Config{Endpoint: test.Address}
+	clientMock := gopcuaMocks.NewMockClient(t)
+	s.client = &Client{clientMock, s.context.ctx}
+	clientMock.On("State").Return(opcua.Connected)
+	mockSend(clientMock, mock.AnythingOfType("*ua.TranslateBrowsePathsToNodeIDsRequest"), &ua.TranslateBrowsePathsToNodeIDsResponse{
+		Results: []*ua.BrowsePathResult{{StatusCode: ua.StatusOK, Targets: []*ua.BrowsePathTarget{{TargetID: ua.NewExpandedNodeID(euRange, "", 0)}}}},
+	}, nil).Once()
+	clientMock.On("Read", test.RequestContext(), mock.MatchedBy(func(r *ua.ReadRequest) bool {
+		return len(r.NodesToRead) == 3 &&
+			r.NodesToRead[0].AttributeID == ua.AttributeIDValue && r.NodesToRead[0].NodeID.String() == node.String() &&
+			r.NodesToRead[1].AttributeID == ua.AttributeIDDisplayName && r.NodesToRead[1].NodeID.String() == node.String() &&
+			r.NodesToRead[2].AttributeID == ua.AttributeIDValue && r.NodesToRead[2].NodeID.String() == euRange.String()
+	})).Return(&ua.ReadResponse{Results: []*ua.DataValue{
+		{Value: ua.MustVariant(21.5)},
+		{Value: ua.MustVariant(ua.NewLocalizedText("Boiler temperature"))},
+		{Value: ua.MustVariant(ua.NewExtensionObject(&ua.Range{Low: 0, High: 120}))},
+	}}, nil).Once()
+
+	got, err := s.ProcessReadCommands(reqs)
+	require.NoError(t, err)
+	assert.Equal(t, 21.5, got[0].Value)
+	assert.Equal(t, "Boiler temperature", got[1].Value)
+	assert.Equal(t, map[string]any{"Low": 0.0, "High": 120.0}, got[2].Value)
+}
@@ -0,0 +1,306 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2025 Schneider Electric
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package server
+
+import (
+	"context"
+	"crypto/x509"
+	"fmt"
+	"time"
+
+	"github.com/gopcua/opcua"
+	"github.com/gopcua/opcua/ua"
+)
+
+const (
+	AuthModeAnonymous   string = "Anonymous"
+	AuthModeUserName    string = "UserName"
+	AuthModeCertificate string = "Certificate"
+	AuthModeIssued      string = "Issued"
+
+	// Keys expected in the secret referenced by Config.SecretName
+	SecretUsernameKey     string = "username"
+	SecretPasswordKey     string = "password"
+	SecretClientIDKey     string = "clientId"
+	SecretClientSecretKey string = "clientSecret"
+
+	// tokenRetryInterval is the delay before retrying a failed token refresh
+	tokenRetryInterval = 5 * time.Second
+)
+
+// authOptions returns the client options for the user identity configured for the device
+func (s *Server) authOptions(ep *ua.EndpointDescription) ([]opcua.Option, error) {
+	switch s.config.AuthMode {
+	case "", AuthModeAnonymous:
+		return []opcua.Option{
+			opcua.AuthAnonymous(),
+			opcua.SecurityFromEndpoint(ep, ua.UserTokenTypeAnonymous),
+		}, nil
+	case AuthModeUserName:
+		if _, err := userTokenPolicy(ep, ua.UserTokenTypeUserName); err != nil {
+			return nil, fmt.Errorf("[%s] %v", s.deviceName, err)
+		}
+		secrets, err := s.sdk.SecretProvider().GetSecret(s.config.SecretName, SecretUsernameKey, SecretPasswordKey)
+		if err != nil {
+			return nil, fmt.Errorf("[%s] unable to get credentials from secret %s: %v", s.deviceName, s.config.SecretName, err)
+		}
+		return []opcua.Option{
+			opcua.AuthUsername(secrets[SecretUsernameKey], secrets[SecretPasswordKey]),
+			opcua.SecurityFromEndpoint(ep, ua.UserTokenTypeUserName),
+		}, nil
+	case AuthModeCertificate:
+		if _, err := userTokenPolicy(ep, ua.UserTokenTypeCertificate); err != nil {
+			return nil, fmt.Errorf("[%s] %v", s.deviceName, err)
+		}
+		cert, err := loadCertificate(s.config.UserCertFile)
+		if err != nil {
+			return nil, fmt.Errorf("[%s] invalid user certificate: %v", s.deviceName, err)
+		}
+		key, err := loadPrivateKey(s.config.UserKeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("[%s] invalid user private key: %v", s.deviceName, err)
+		}
+		return []opcua.Option{
+			opcua.AuthCertificate(cert),
+			opcua.AuthPrivateKey(key),
+			opcua.SecurityFromEndpoint(ep, ua.UserTokenTypeCertificate),
+		}, nil
+	case AuthModeIssued:
+		if _, err := userTokenPolicy(ep, ua.UserTokenTypeIssuedToken); err != nil {
+			return nil, fmt.Errorf("[%s] %v", s.deviceName, err)
+		}
+		tokens, err := s.tokenSource()
+		if err != nil {
+			return nil, err
+		}
+		token, _, err := tokens.Token(s.context.ctx)
+		if err != nil {
+			return nil, fmt.Errorf("[%s] unable to get issued token from %s: %v", s.deviceName, s.config.TokenEndpoint, err)
+		}
+		return []opcua.Option{
+			opcua.AuthIssuedToken(token),
+			opcua.SecurityFromEndpoint(ep, ua.UserTokenTypeIssuedToken),
+		}, nil
+	default:
+		return nil, fmt.Errorf("[%s] unsupported auth mode: %s", s.deviceName, s.config.AuthMode)
+	}
+}
+
+// userTokenType returns the user token type matching the configured auth mode
+func (s *Server) userTokenType() ua.UserTokenType {
+	switch s.config.AuthMode {
+	case AuthModeUserName:
+		return ua.UserTokenTypeUserName
+	case AuthModeCertificate:
+		return ua.UserTokenTypeCertificate
+	case AuthModeIssued:
+		return ua.UserTokenTypeIssuedToken
+	default:
+		return ua.UserTokenTypeAnonymous
+	}
+}
+
+// userTokenPolicy returns the first policy of the endpoint accepting the given user token type
+func userTokenPolicy(ep *ua.EndpointDescription, tokenType ua.UserTokenType) (*ua.UserTokenPolicy, error) {
+	for _, policy := range ep.UserIdentityTokens {
+		if policy.TokenType == tokenType {
+			return policy, nil
+		}
+	}
+	return nil, fmt.Errorf("endpoint %s (%s, %s) does not offer a %s user token policy",
+		ep.EndpointURL, ep.SecurityPolicyURI, ep.SecurityMode, tokenType)
+}
+
+// tokenSource returns the token source for the configured identity provider,
+// keeping the cached token as long as the provider and client are unchanged
+func (s *Server) tokenSource() (*tokenSource, error) {
+	secrets, err := s.sdk.SecretProvider().GetSecret(s.config.SecretName, SecretClientIDKey, SecretClientSecretKey)
+	if err != nil {
+		return nil, fmt.Errorf("[%s] unable to get client credentials from secret %s: %v", s.deviceName, s.config.SecretName, err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.tokens == nil || s.tokens.endpoint != s.config.TokenEndpoint || s.tokens.scope != s.config.TokenScope ||
+		s.tokens.clientID != secrets[SecretClientIDKey] || s.tokens.clientSecret != secrets[SecretClientSecretKey] {
+		s.tokens = newTokenSource(s.config.TokenEndpoint, secrets[SecretClientIDKey], secrets[SecretClientSecretKey], s.config.TokenScope)
+	}
+	return s.tokens, nil
+}
+
+// refreshIssuedToken renews the issued token before it expires and reactivates the
+// session of client with it, until ctx is cancelled or the client is replaced
+func (s *Server) refreshIssuedToken(ctx context.Context, client *Client) {
+	s.mu.Lock()
+	tokens := s.tokens
+	s.mu.Unlock()
+	if tokens == nil {
+		return
+	}
+
+	for {
+		wait := tokenRetryInterval
+		if _, expiry, err := tokens.Token(ctx); err == nil {
+			wait = tokenRefreshWait(expiry)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(wait):
+		}
+
+		s.mu.Lock()
+		current := s.client
+		s.mu.Unlock()
+		if current != client {
+			return
+		}
+
+		token, _, err := tokens.Token(ctx)
+		if err != nil {
+			s.sdk.LoggingClient().Warnf("[%s] failed to refresh issued token: %v", s.deviceName, err)
+			continue
+		}
+		if err := s.reactivateSession(client, token); err != nil {
+			s.sdk.LoggingClient().Warnf("[%s] failed to reactivate session with refreshed token: %v", s.deviceName, err)
+			continue
+		}
+		s.sdk.LoggingClient().Debugf("[%s] session reactivated with refreshed token", s.deviceName)
+	}
+}
+
+// reactivateSession activates a new session carrying the given issued token, and
+// transfers the subscriptions of the current session to it so that they survive the renewal.
+// The identity token of an existing session cannot be replaced through the OPC UA client
+// library, the previous session is closed once its subscriptions are transferred.
+func (s *Server) reactivateSession(client *Client, token []byte) error {
+	s.mu.Lock()
+	ep := s.endpoint
+	ps := s.session
+	s.mu.Unlock()
+	if ep == nil {
+		return fmt.Errorf("no endpoint selected")
+	}
+
+	policy, err := userTokenPolicy(ep, ua.UserTokenTypeIssuedToken)
+	if err != nil {
+		return err
+	}
+
+	cfg := opcua.DefaultSessionConfig()
+	if uri := s.applicationURI(); uri != "" {
+		cfg.ClientDescription.ApplicationURI = uri
+	}
+	cfg.UserIdentityToken = &ua.IssuedIdentityToken{PolicyID: policy.PolicyID, TokenData: token}
+	cfg.AuthPolicyURI = policy.SecurityPolicyURI
+	if cfg.AuthPolicyURI == "" {
+		cfg.AuthPolicyURI = ep.SecurityPolicyURI
+	}
+
+	session, err := client.CreateSession(client.ctx, cfg)
+	if err != nil {
+		return fmt.Errorf("create session failed: %v", err)
+	}
+
+	previous, err := client.DetachSession(client.ctx)
+	if err != nil {
+		return fmt.Errorf("detach session failed: %v", err)
+	}
+	if err := client.ActivateSession(client.ctx, session); err != nil {
+		if previous != nil {
+			// keep using the previous session, its token might still be valid
+			_ = client.ActivateSession(client.ctx, previous)
+		}
+		return fmt.Errorf("activate session failed: %v", err)
+	}
+
+	// the subscriptions published by the session are not known to the client library
+	ids := client.SubscriptionIDs()
+	if ps != nil {
+		ids = append(ids, ps.publishedIDs()...)
+	}
+	if len(ids) > 0 {
+		if err := transferSubscriptions(client, ids); err != nil {
+			// the subscriptions stayed on the detached session and no longer publish, the connection
+			// is closed so that the subscription supervisor connects again and recovers them
+			_ = client.Close(client.ctx)
+			return fmt.Errorf("%v, connection closed to recover the subscriptions", err)
+		}
+	}
+	if err := s.closePreviousSession(client, session, previous); err != nil {
+		_ = client.Close(client.ctx)
+		return fmt.Errorf("%v, connection closed", err)
+	}
+	return nil
+}
+
+// transferSubscriptions moves the subscriptions of the previous session to the current one
+func transferSubscriptions(client *Client, ids []uint32) error {
+	req := &ua.TransferSubscriptionsRequest{SubscriptionIDs: ids}
+	return client.Send(client.ctx, req, func(v ua.Response) error {
+		res, ok := v.(*ua.TransferSubscriptionsResponse)
+		if !ok {
+			return fmt.Errorf("unexpected response type %T", v)
+		}
+		for i, result := range res.Results {
+			if result.StatusCode != ua.StatusOK {
+				return fmt.Errorf("transfer of subscription %d failed: %v", ids[i], result.StatusCode)
+			}
+		}
+		return nil
+	})
+}
+
+// closePreviousSession closes the session replaced by the renewal, keeping the subscriptions transferred
+// from it. The client library only closes its current session, so the previous one is activated
+// again to be closed. An error is only returned when the new session cannot be restored.
+func (s *Server) closePreviousSession(client *Client, current, previous *opcua.Session) error {
+	if previous == nil {
+		return nil
+	}
+
+	err := switchSession(client, previous)
+	if err == nil {
+		req := &ua.CloseSessionRequest{DeleteSubscriptions: false}
+		err = client.Send(client.ctx, req, func(ua.Response) error { return nil })
+	}
+	if err != nil {
+		s.sdk.LoggingClient().Warnf("[%s] failed to close the previous session, left to expire: %v", s.deviceName, err)
+	}
+
+	if err := switchSession(client, current); err != nil {
+		return fmt.Errorf("activate renewed session failed: %v", err)
+	}
+	return nil
+}
+
+// switchSession makes the session the current session of the client, the replaced one is not closed
+func switchSession(client *Client, session *opcua.Session) error {
+	if _, err := client.DetachSession(client.ctx); err != nil {
+		return err
+	}
+	return client.ActivateSession(client.ctx, session)
+}
+
+// applicationURI returns the application URI of the configured client certificate
+func (s *Server) applicationURI() string {
+	certFile, _ := s.certificateFiles()
+	if certFile == "" {
+		return ""
+	}
+	der, err := loadCertificate(certFile)
+	if err != nil {
+		return ""
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil || len(cert.URIs) == 0 {
+		return ""
+	}
+	return cert.URIs[0].String()
+}
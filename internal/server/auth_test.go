@@ -0,0 +1,268 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2025 Schneider Electric
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package server
+
+import (
+	"fmt"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/edgexfoundry/device-opcua-go/internal/test"
+	gopcuaMocks "github.com/edgexfoundry/device-opcua-go/pkg/gopcua/mocks"
+	bootstrapMocks "github.com/edgexfoundry/go-mod-bootstrap/v4/bootstrap/interfaces/mocks"
+	"github.com/gopcua/opcua"
+	"github.com/gopcua/opcua/ua"
+	"github.com/gopcua/opcua/uasc"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestServer_authOptions(t *testing.T) {
+	dir := t.TempDir()
+	certFile, keyFile := test.CreateCertificate(t, dir, time.Now().Add(time.Hour))
+
+	ep := &ua.EndpointDescription{
+		SecurityPolicyURI: ua.SecurityPolicyURINone,
+		SecurityMode:      ua.MessageSecurityModeNone,
+		UserIdentityTokens: []*ua.UserTokenPolicy{
+			{PolicyID: "anonymous", TokenType: ua.UserTokenTypeAnonymous},
+			{PolicyID: "username", TokenType: ua.UserTokenTypeUserName},
+			{PolicyID: "certificate", TokenType: ua.UserTokenTypeCertificate},
+			{PolicyID: "issued", TokenType: ua.UserTokenTypeIssuedToken},
+		},
+	}
+	tokenServer, _ := newTokenServer(t, 3600)
+	anonymousOnly := &ua.EndpointDescription{
+		UserIdentityTokens: []*ua.UserTokenPolicy{{PolicyID: "anonymous", TokenType: ua.UserTokenTypeAnonymous}},
+	}
+
+	tests := []struct {
+		name      string
+		config    *Config
+		endpoint  *ua.EndpointDescription
+		secrets   map[string]string
+		secretErr error
+		wantOpts  int
+		wantErr   bool
+	}{
+		{
+			name:     "OK - default is anonymous",
+			config:   &Config{},
+			wantOpts: 2,
+		},
+		{
+			name:     "OK - anonymous",
+			config:   &Config{AuthMode: AuthModeAnonymous},
+			wantOpts: 2,
+		},
+		{
+			name:     "OK - username from secret store",
+			config:   &Config{AuthMode: AuthModeUserName, SecretName: "opcua-user"},
+			secrets:  map[string]string{SecretUsernameKey: "user", SecretPasswordKey: "pass"},
+			wantOpts: 2,
+		},
+		{
+			name:      "NOK - secret not found",
+			config:    &Config{AuthMode: AuthModeUserName, SecretName: "opcua-user"},
+			secretErr: fmt.Errorf("secret not found"),
+			wantErr:   true,
+		},
+		{
+			name:     "NOK - username token not offered by endpoint",
+			config:   &Config{AuthMode: AuthModeUserName, SecretName: "opcua-user"},
+			endpoint: anonymousOnly,
+			wantErr:  true,
+		},
+		{
+			name:     "OK - user certificate",
+			config:   &Config{AuthMode: AuthModeCertificate, UserCertFile: certFile, UserKeyFile: keyFile},
+			wantOpts: 3,
+		},
+		{
+			name:     "NOK - certificate token not offered by endpoint",
+			config:   &Config{AuthMode: AuthModeCertificate, UserCertFile: certFile, UserKeyFile: keyFile},
+			endpoint: anonymousOnly,
+			wantErr:  true,
+		},
+		{
+			name:    "NOK - user certificate file missing",
+			config:  &Config{AuthMode: AuthModeCertificate, UserCertFile: filepath.Join(dir, "missing.pem"), UserKeyFile: keyFile},
+			wantErr: true,
+		},
+		{
+			name:    "NOK - user key file is not a key",
+			config:  &Config{AuthMode: AuthModeCertificate, UserCertFile: certFile, UserKeyFile: certFile},
+			wantErr: true,
+		},
+		{
+			name:     "OK - issued token",
+			config:   &Config{AuthMode: AuthModeIssued, SecretName: "opcua-idp", TokenEndpoint: tokenServer.URL},
+			secrets:  map[string]string{SecretClientIDKey: "client", SecretClientSecretKey: "secret"},
+			wantOpts: 2,
+		},
+		{
+			name:     "NOK - issued token rejected by identity provider",
+			config:   &Config{AuthMode: AuthModeIssued, SecretName: "opcua-idp", TokenEndpoint: tokenServer.URL},
+			secrets:  map[string]string{SecretClientIDKey: "client", SecretClientSecretKey: "wrong"},
+			wantErr:  true,
+			wantOpts: 0,
+		},
+		{
+			name:     "NOK - issued token not offered by endpoint",
+			config:   &Config{AuthMode: AuthModeIssued, SecretName: "opcua-idp", TokenEndpoint: tokenServer.URL},
+			endpoint: anonymousOnly,
+			wantErr:  true,
+		},
+		{
+			name:    "NOK - unsupported auth mode",
+			config:  &Config{AuthMode: "Kerberos"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dsMock := test.NewDSMock(t)
+			endpoint := ep
+			if tt.endpoint != nil {
+				endpoint = tt.endpoint
+			}
+			if tt.endpoint == nil {
+				secretMock := bootstrapMocks.NewSecretProvider(t)
+				switch tt.config.AuthMode {
+				case AuthModeUserName:
+					secretMock.On("GetSecret", tt.config.SecretName, SecretUsernameKey, SecretPasswordKey).Return(tt.secrets, tt.secretErr)
+					dsMock.On("SecretProvider").Return(secretMock)
+				case AuthModeIssued:
+					secretMock.On("GetSecret", tt.config.SecretName, SecretClientIDKey, SecretClientSecretKey).Return(tt.secrets, tt.secretErr)
+					dsMock.On("SecretProvider").Return(secretMock)
+				}
+			}
+
+			s := NewServer("Test", dsMock, nil)
+			s.config = tt.config
+			got, err := s.authOptions(endpoint)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Server.authOptions() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if len(got) != tt.wantOpts {
+				t.Errorf("Server.authOptions() returned %d options, want %d", len(got), tt.wantOpts)
+			}
+		})
+	}
+}
+
+func Test_userTokenPolicy(t *testing.T) {
+	ep := &ua.EndpointDescription{
+		UserIdentityTokens: []*ua.UserTokenPolicy{
+			{PolicyID: "anonymous", TokenType: ua.UserTokenTypeAnonymous},
+			{PolicyID: "x509", TokenType: ua.UserTokenTypeCertificate},
+		},
+	}
+
+	t.Run("OK - matching policy", func(t *testing.T) {
+		policy, err := userTokenPolicy(ep, ua.UserTokenTypeCertificate)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if policy.PolicyID != "x509" {
+			t.Errorf("userTokenPolicy() = %s, want x509", policy.PolicyID)
+		}
+	})
+
+	t.Run("NOK - token type not offered", func(t *testing.T) {
+		if _, err := userTokenPolicy(ep, ua.UserTokenTypeIssuedToken); err == nil {
+			t.Error("expected an error but got none")
+		}
+	})
+}
+
+func TestServer_reactivateSession(t *testing.T) {
+	ep := &ua.EndpointDescription{
+		SecurityPolicyURI:  ua.SecurityPolicyURINone,
+		UserIdentityTokens: []*ua.UserTokenPolicy{{PolicyID: "issued", TokenType: ua.UserTokenTypeIssuedToken}},
+	}
+	isIssuedToken := mock.MatchedBy(func(cfg *uasc.SessionConfig) bool {
+		tok, ok := cfg.UserIdentityToken.(*ua.IssuedIdentityToken)
+		return ok && tok.PolicyID == "issued" && string(tok.TokenData) == "token"
+	})
+
+	t.Run("OK - subscriptions transferred to the new session", func(t *testing.T) {
+		clientMock := gopcuaMocks.NewMockClient(t)
+		session := &opcua.Session{}
+		previous := &opcua.Session{}
+		clientMock.On("CreateSession", mock.Anything, isIssuedToken).Return(session, nil)
+		clientMock.On("DetachSession", mock.Anything).Return(previous, nil).Once()
+		clientMock.On("ActivateSession", mock.Anything, session).Return(nil).Twice()
+		clientMock.On("SubscriptionIDs").Return([]uint32{7})
+		// the previous session is activated again to be closed, keeping the transferred subscriptions
+		clientMock.On("DetachSession", mock.Anything).Return(session, nil).Once()
+		clientMock.On("ActivateSession", mock.Anything, previous).Return(nil).Once()
+		clientMock.On("Send", mock.Anything, &ua.CloseSessionRequest{DeleteSubscriptions: false}, mock.Anything).Return(nil).Once()
+		clientMock.On("DetachSession", mock.Anything).Return(previous, nil).Once()
+		clientMock.On("Send", mock.Anything, &ua.TransferSubscriptionsRequest{SubscriptionIDs: []uint32{7}}, mock.Anything).
+			Run(func(args mock.Arguments) {
+				h := args.Get(2).(func(ua.Response) error)
+				_ = h(&ua.TransferSubscriptionsResponse{Results: []*ua.TransferResult{{StatusCode: ua.StatusOK}}})
+			}).Return(nil)
+
+		s := NewServer("Test", test.NewDSMock(t), nil)
+		s.config = &Config{}
+		s.endpoint = ep
+		client := &Client{clientMock, s.context.ctx}
+		if err := s.reactivateSession(client, []byte("token")); err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("NOK - transfer failure closes the connection", func(t *testing.T) {
+		clientMock := gopcuaMocks.NewMockClient(t)
+		session := &opcua.Session{}
+		clientMock.On("CreateSession", mock.Anything, isIssuedToken).Return(session, nil)
+		clientMock.On("DetachSession", mock.Anything).Return(&opcua.Session{}, nil).Once()
+		clientMock.On("ActivateSession", mock.Anything, session).Return(nil).Once()
+		clientMock.On("SubscriptionIDs").Return([]uint32{7})
+		mockSend(clientMock, mock.AnythingOfType("*ua.TransferSubscriptionsRequest"), &ua.TransferSubscriptionsResponse{
+			Results: []*ua.TransferResult{{StatusCode: ua.StatusBadSubscriptionIDInvalid}},
+		}, nil).Once()
+		clientMock.On("Close", mock.Anything).Return(nil).Once()
+
+		s := NewServer("Test", test.NewDSMock(t), nil)
+		s.config = &Config{}
+		s.endpoint = ep
+		client := &Client{clientMock, s.context.ctx}
+		if err := s.reactivateSession(client, []byte("token")); err == nil {
+			t.Error("expected an error but got none")
+		}
+	})
+
+	t.Run("NOK - activation failure restores previous session", func(t *testing.T) {
+		clientMock := gopcuaMocks.NewMockClient(t)
+		session := &opcua.Session{}
+		previous := &opcua.Session{}
+		clientMock.On("CreateSession", mock.Anything, isIssuedToken).Return(session, nil)
+		clientMock.On("DetachSession", mock.Anything).Return(previous, nil)
+		clientMock.On("ActivateSession", mock.Anything, session).Return(ua.StatusBadIdentityTokenRejected)
+		clientMock.On("ActivateSession", mock.Anything, previous).Return(nil)
+
+		s := NewServer("Test", test.NewDSMock(t), nil)
+		s.config = &Config{}
+		s.endpoint = ep
+		client := &Client{clientMock, s.context.ctx}
+		if err := s.reactivateSession(client, []byte("token")); err == nil {
+			t.Error("expected an error but got none")
+		}
+	})
+
+	t.Run("NOK - no endpoint selected", func(t *testing.T) {
+		s := NewServer("Test", test.NewDSMock(t), nil)
+		s.config = &Config{}
+		if err := s.reactivateSession(&Client{}, []byte("token")); err == nil {
+			t.Error("expected an error but got none")
+		}
+	})
+}
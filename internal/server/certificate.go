@@ -0,0 +1,245 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2025 Schneider Electric
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package server
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/clients/logger"
+)
+
+const (
+	// Location of the application instance certificate, relative to OPCUAConfig.PKIDir
+	// unless OPCUAConfig.CertificateDir is set
+	PKIOwnDir          string = "own"
+	ownCertificateFile string = "cert.pem"
+	ownPrivateKeyFile  string = "private/key.pem"
+
+	defaultApplicationName     = "device-opcua"
+	defaultCertificateKeySize  = 2048
+	defaultCertificateValidity = 365 * 24 * time.Hour
+)
+
+// certificateMu serializes the generation of the application certificate shared by all devices
+var certificateMu sync.Mutex
+
+// ApplicationCertificate describes the application instance certificate of the service
+type ApplicationCertificate struct {
+	CertFile   string
+	KeyFile    string
+	Thumbprint string
+	NotAfter   time.Time
+}
+
+// applicationCertificateFiles returns where the generated application certificate is stored
+func applicationCertificateFiles(cfg OPCUAConfig) (certFile, keyFile string) {
+	return filepath.Join(cfg.ownDir(), ownCertificateFile), filepath.Join(cfg.ownDir(), ownPrivateKeyFile)
+}
+
+// EnsureApplicationCertificate loads the application instance certificate stored in the
+// certificate store, and generates a self-signed one if there is none yet or if it is due for renewal
+func EnsureApplicationCertificate(cfg OPCUAConfig, lc logger.LoggingClient) (*ApplicationCertificate, error) {
+	cert, generated, err := ensureApplicationCertificate(cfg)
+	if err != nil {
+		return nil, err
+	}
+	if generated {
+		lc.Infof("Generated application certificate %s, thumbprint %s, valid until %s", cert.CertFile, cert.Thumbprint, cert.NotAfter)
+	} else {
+		lc.Infof("Using application certificate %s, thumbprint %s, valid until %s", cert.CertFile, cert.Thumbprint, cert.NotAfter)
+	}
+	return cert, nil
+}
+
+func ensureApplicationCertificate(cfg OPCUAConfig) (*ApplicationCertificate, bool, error) {
+	if !cfg.GeneratesCertificate() {
+		return nil, false, fmt.Errorf("unable to store the application certificate: neither CertificateDir nor PKIDir configured")
+	}
+
+	certificateMu.Lock()
+	defer certificateMu.Unlock()
+
+	renewBefore, err := certificateRenewal(cfg)
+	if err != nil {
+		return nil, false, err
+	}
+
+	certFile, keyFile := applicationCertificateFiles(cfg)
+	cert, err := readApplicationCertificate(certFile, keyFile)
+	if err == nil && time.Until(cert.NotAfter) > renewBefore {
+		return cert, false, nil
+	}
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, false, err
+	}
+
+	cert, err = generateApplicationCertificate(cfg, certFile, keyFile)
+	if err != nil {
+		return nil, false, fmt.Errorf("unable to generate the application certificate: %v", err)
+	}
+	return cert, true, nil
+}
+
+// certificateRenewal returns how long before expiry the generated certificate is renewed: at the
+// smallest expiry warning, the last one logged, or at half its validity for certificates shorter
+// lived than the warning
+func certificateRenewal(cfg OPCUAConfig) (time.Duration, error) {
+	warnings, err := certificateExpiryWarnings(cfg)
+	if err != nil {
+		return 0, err
+	}
+	validity, err := certificateValidity(cfg)
+	if err != nil {
+		return 0, err
+	}
+	return min(warnings[0], validity/2), nil
+}
+
+// certificateValidity returns the lifetime of the generated certificate
+func certificateValidity(cfg OPCUAConfig) (time.Duration, error) {
+	if cfg.CertificateValidity == "" {
+		return defaultCertificateValidity, nil
+	}
+	d, err := time.ParseDuration(cfg.CertificateValidity)
+	if err != nil || d <= 0 {
+		return 0, fmt.Errorf("invalid certificate validity %s", cfg.CertificateValidity)
+	}
+	return d, nil
+}
+
+// readApplicationCertificate loads a certificate and its private key, and makes sure they belong together
+func readApplicationCertificate(certFile, keyFile string) (*ApplicationCertificate, error) {
+	for _, filename := range []string{certFile, keyFile} {
+		if _, err := os.Stat(filename); err != nil {
+			return nil, err
+		}
+	}
+
+	der, err := loadCertificate(certFile)
+	if err != nil {
+		return nil, err
+	}
+	key, err := loadPrivateKey(keyFile)
+	if err != nil {
+		return nil, err
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		return nil, err
+	}
+	if !key.PublicKey.Equal(cert.PublicKey) {
+		return nil, fmt.Errorf("private key %s does not match certificate %s", keyFile, certFile)
+	}
+
+	return &ApplicationCertificate{
+		CertFile:   certFile,
+		KeyFile:    keyFile,
+		Thumbprint: thumbprint(der),
+		NotAfter:   cert.NotAfter,
+	}, nil
+}
+
+func generateApplicationCertificate(cfg OPCUAConfig, certFile, keyFile string) (*ApplicationCertificate, error) {
+	keySize := cfg.CertificateKeySize
+	if keySize == 0 {
+		keySize = defaultCertificateKeySize
+	}
+	if keySize != 2048 && keySize != 3072 && keySize != 4096 {
+		return nil, fmt.Errorf("unsupported key size %d", keySize)
+	}
+
+	validity, err := certificateValidity(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	hostname, err := os.Hostname()
+	if err != nil {
+		hostname = "localhost"
+	}
+
+	appURI := cfg.ApplicationURI
+	if appURI == "" {
+		appURI = fmt.Sprintf("urn:%s:edgex:%s", hostname, defaultApplicationName)
+	}
+	uri, err := url.Parse(appURI)
+	if err != nil {
+		return nil, fmt.Errorf("invalid application URI %s: %v", appURI, err)
+	}
+
+	key, err := rsa.GenerateKey(rand.Reader, keySize)
+	if err != nil {
+		return nil, err
+	}
+
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	template := &x509.Certificate{
+		SerialNumber: serial,
+		Subject: pkix.Name{
+			CommonName:   defaultApplicationName,
+			Organization: []string{"EdgeX Foundry"},
+		},
+		NotBefore: now.Add(-time.Hour),
+		NotAfter:  now.Add(validity),
+		KeyUsage: x509.KeyUsageDigitalSignature | x509.KeyUsageContentCommitment |
+			x509.KeyUsageKeyEncipherment | x509.KeyUsageDataEncipherment | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		BasicConstraintsValid: true,
+		URIs:                  []*url.URL{uri},
+		DNSNames:              []string{hostname},
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(keyFile), 0700); err != nil {
+		return nil, err
+	}
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+	if err := os.WriteFile(keyFile, keyPEM, 0600); err != nil {
+		return nil, err
+	}
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	if err := os.WriteFile(certFile, certPEM, 0600); err != nil {
+		return nil, err
+	}
+
+	return &ApplicationCertificate{
+		CertFile:   certFile,
+		KeyFile:    keyFile,
+		Thumbprint: thumbprint(der),
+		NotAfter:   template.NotAfter,
+	}, nil
+}
+
+// certificateFiles returns the application certificate of the device, or the one
+// of the service when the device does not configure its own
+func (s *Server) certificateFiles() (certFile, keyFile string) {
+	if s.config.CertFile != "" || s.serviceConfig == nil || !s.serviceConfig.OPCUA.GeneratesCertificate() {
+		return s.config.CertFile, s.config.KeyFile
+	}
+	return applicationCertificateFiles(s.serviceConfig.OPCUA)
+}
@@ -0,0 +1,139 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2025 Schneider Electric
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package server
+
+import (
+	"crypto/x509"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/edgexfoundry/device-opcua-go/internal/test"
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/clients/logger"
+)
+
+func TestEnsureApplicationCertificate(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     OPCUAConfig
+		wantErr bool
+	}{
+		{
+			name:    "NOK - no PKI directory",
+			cfg:     OPCUAConfig{},
+			wantErr: true,
+		},
+		{
+			name:    "NOK - unsupported key size",
+			cfg:     OPCUAConfig{PKIDir: t.TempDir(), CertificateKeySize: 1024},
+			wantErr: true,
+		},
+		{
+			name:    "NOK - invalid validity",
+			cfg:     OPCUAConfig{PKIDir: t.TempDir(), CertificateValidity: "forever"},
+			wantErr: true,
+		},
+		{
+			name: "OK - generated with defaults",
+			cfg:  OPCUAConfig{PKIDir: t.TempDir()},
+		},
+		{
+			name: "OK - generated without certificate store",
+			cfg:  OPCUAConfig{CertificateDir: t.TempDir()},
+		},
+		{
+			name: "OK - generated with application URI",
+			cfg:  OPCUAConfig{PKIDir: t.TempDir(), ApplicationURI: test.ApplicationURI, CertificateValidity: "24h"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := EnsureApplicationCertificate(tt.cfg, logger.NewMockClient())
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("EnsureApplicationCertificate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+
+			der, err := loadCertificate(got.CertFile)
+			if err != nil {
+				t.Fatalf("loadCertificate() error = %v", err)
+			}
+			cert, err := x509.ParseCertificate(der)
+			if err != nil {
+				t.Fatalf("ParseCertificate() error = %v", err)
+			}
+			if len(cert.URIs) != 1 || (tt.cfg.ApplicationURI != "" && cert.URIs[0].String() != tt.cfg.ApplicationURI) {
+				t.Errorf("unexpected application URI %v", cert.URIs)
+			}
+			if tt.cfg.CertificateValidity == "24h" && cert.NotAfter.After(time.Now().Add(25*time.Hour)) {
+				t.Errorf("unexpected validity %s", cert.NotAfter)
+			}
+
+			// the stored certificate is reused on the next start
+			again, err := EnsureApplicationCertificate(tt.cfg, logger.NewMockClient())
+			if err != nil {
+				t.Fatalf("EnsureApplicationCertificate() error = %v", err)
+			}
+			if again.Thumbprint != got.Thumbprint {
+				t.Errorf("certificate regenerated, thumbprint %s, want %s", again.Thumbprint, got.Thumbprint)
+			}
+		})
+	}
+}
+
+func TestServer_certificateFiles(t *testing.T) {
+	dir, certDir := t.TempDir(), t.TempDir()
+	certFile, keyFile := applicationCertificateFiles(OPCUAConfig{PKIDir: dir})
+
+	tests := []struct {
+		name          string
+		config        *Config
+		serviceConfig *ServiceConfig
+		wantCert      string
+		wantKey       string
+	}{
+		{
+			name:     "OK - no service configuration",
+			config:   &Config{},
+			wantCert: "",
+			wantKey:  "",
+		},
+		{
+			name:          "OK - device certificate",
+			config:        &Config{CertFile: "cert.pem", KeyFile: "key.pem"},
+			serviceConfig: &ServiceConfig{OPCUA: OPCUAConfig{PKIDir: dir}},
+			wantCert:      "cert.pem",
+			wantKey:       "key.pem",
+		},
+		{
+			name:          "OK - generated certificate",
+			config:        &Config{},
+			serviceConfig: &ServiceConfig{OPCUA: OPCUAConfig{PKIDir: dir}},
+			wantCert:      certFile,
+			wantKey:       keyFile,
+		},
+		{
+			name:          "OK - generated certificate in certificate directory",
+			config:        &Config{},
+			serviceConfig: &ServiceConfig{OPCUA: OPCUAConfig{PKIDir: dir, CertificateDir: certDir}},
+			wantCert:      filepath.Join(certDir, ownCertificateFile),
+			wantKey:       filepath.Join(certDir, ownPrivateKeyFile),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewServer("Test", test.NewDSMock(t), tt.serviceConfig)
+			s.config = tt.config
+			gotCert, gotKey := s.certificateFiles()
+			if gotCert != tt.wantCert || gotKey != tt.wantKey {
+				t.Errorf("certificateFiles() = %s, %s, want %s, %s", gotCert, gotKey, tt.wantCert, tt.wantKey)
+			}
+		})
+	}
+}
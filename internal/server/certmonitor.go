@@ -0,0 +1,179 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2025 Schneider Electric
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package server
+
+import (
+	"context"
+	"crypto/x509"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/clients/logger"
+)
+
+const defaultCertificateCheckInterval = time.Hour
+
+// defaultCertificateExpiryWarnings are sorted, smallest threshold first
+var defaultCertificateExpiryWarnings = []time.Duration{24 * time.Hour, 7 * 24 * time.Hour, 30 * 24 * time.Hour}
+
+// CertificateMonitor periodically checks the application certificates used by the servers.
+// It warns before they expire, renews the generated certificate at the last expiry warning, the smallest
+// threshold, and restarts the servers whose certificate file has been replaced.
+type CertificateMonitor struct {
+	cfg      OPCUAConfig
+	lc       logger.LoggingClient
+	servers  func() []*Server
+	interval time.Duration
+	warnings []time.Duration
+	// warned holds the last threshold reported for each certificate thumbprint
+	warned map[string]time.Duration
+}
+
+// NewCertificateMonitor creates a monitor for the servers returned by the servers function
+func NewCertificateMonitor(cfg OPCUAConfig, lc logger.LoggingClient, servers func() []*Server) (*CertificateMonitor, error) {
+	m := &CertificateMonitor{
+		cfg:      cfg,
+		lc:       lc,
+		servers:  servers,
+		interval: defaultCertificateCheckInterval,
+		warned:   make(map[string]time.Duration),
+	}
+
+	if cfg.CertificateCheckInterval != "" {
+		d, err := time.ParseDuration(cfg.CertificateCheckInterval)
+		if err != nil || d <= 0 {
+			return nil, fmt.Errorf("invalid certificate check interval %s", cfg.CertificateCheckInterval)
+		}
+		m.interval = d
+	}
+
+	warnings, err := certificateExpiryWarnings(cfg)
+	if err != nil {
+		return nil, err
+	}
+	m.warnings = warnings
+
+	return m, nil
+}
+
+// certificateExpiryWarnings returns the configured expiry warnings, smallest threshold first
+// so the first match is the closest one to the expiry
+func certificateExpiryWarnings(cfg OPCUAConfig) ([]time.Duration, error) {
+	if len(cfg.CertificateExpiryWarnings) == 0 {
+		return defaultCertificateExpiryWarnings, nil
+	}
+
+	warnings := make([]time.Duration, 0, len(cfg.CertificateExpiryWarnings))
+	for _, w := range cfg.CertificateExpiryWarnings {
+		d, err := time.ParseDuration(w)
+		if err != nil || d <= 0 {
+			return nil, fmt.Errorf("invalid certificate expiry warning %s", w)
+		}
+		warnings = append(warnings, d)
+	}
+	sort.Slice(warnings, func(i, j int) bool { return warnings[i] < warnings[j] })
+	return warnings, nil
+}
+
+// Run checks the certificates at every interval until ctx is cancelled
+func (m *CertificateMonitor) Run(ctx context.Context) {
+	ticker := time.NewTicker(m.interval)
+	defer ticker.Stop()
+
+	m.Check()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			m.Check()
+		}
+	}
+}
+
+// Check runs a single verification of the application certificates
+func (m *CertificateMonitor) Check() {
+	if m.cfg.GeneratesCertificate() {
+		cert, generated, err := ensureApplicationCertificate(m.cfg)
+		if err != nil {
+			m.lc.Errorf("Application certificate check failed: %v", err)
+		} else if generated {
+			m.lc.Infof("Renewed application certificate %s, thumbprint %s, valid until %s", cert.CertFile, cert.Thumbprint, cert.NotAfter)
+		}
+	}
+
+	checked := make(map[string]bool)
+	for _, s := range m.servers() {
+		certFile, rotated := s.certificateRotated()
+		if certFile != "" && !checked[certFile] {
+			checked[certFile] = true
+			m.checkExpiry(certFile)
+		}
+
+		if rotated {
+			m.lc.Infof("[%s] application certificate changed, reconnecting", s.deviceName)
+			s.Restart()
+		}
+	}
+}
+
+func (m *CertificateMonitor) checkExpiry(certFile string) {
+	der, err := loadCertificate(certFile)
+	if err != nil {
+		m.lc.Warnf("Unable to check application certificate %s: %v", certFile, err)
+		return
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		m.lc.Warnf("Unable to check application certificate %s: %v", certFile, err)
+		return
+	}
+
+	tp := thumbprint(der)
+	remaining := time.Until(cert.NotAfter)
+	if remaining <= 0 {
+		m.lc.Errorf("Application certificate %s (thumbprint %s) expired on %s", certFile, tp, cert.NotAfter)
+		return
+	}
+
+	for _, w := range m.warnings {
+		if remaining > w {
+			continue
+		}
+		if last, ok := m.warned[tp]; !ok || w < last {
+			m.warned[tp] = w
+			m.lc.Warnf("Application certificate %s (thumbprint %s) expires in %s, on %s",
+				certFile, tp, remaining.Truncate(time.Minute), cert.NotAfter)
+		}
+		return
+	}
+}
+
+// certificateRotated returns the application certificate of a connected server, and whether
+// it has been replaced by a valid certificate and key pair since the connection was made
+func (s *Server) certificateRotated() (string, bool) {
+	s.mu.Lock()
+	if s.client == nil || s.config == nil {
+		s.mu.Unlock()
+		return "", false
+	}
+	certFile, keyFile := s.certificateFiles()
+	current := s.certificate
+	s.mu.Unlock()
+
+	if current == "" {
+		return certFile, false
+	}
+
+	cert, err := readApplicationCertificate(certFile, keyFile)
+	if err != nil {
+		// the new pair may be partially written, it is picked up on the next check
+		return certFile, false
+	}
+	return certFile, cert.Thumbprint != current
+}
@@ -0,0 +1,267 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2025 Schneider Electric
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package server
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/edgexfoundry/device-opcua-go/internal/test"
+	gopcuaMocks "github.com/edgexfoundry/device-opcua-go/pkg/gopcua/mocks"
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/clients/logger"
+)
+
+func TestNewCertificateMonitor(t *testing.T) {
+	tests := []struct {
+		name         string
+		cfg          OPCUAConfig
+		wantInterval time.Duration
+		wantWarnings []time.Duration
+		wantErr      bool
+	}{
+		{
+			name:         "OK - defaults",
+			cfg:          OPCUAConfig{},
+			wantInterval: defaultCertificateCheckInterval,
+			wantWarnings: []time.Duration{24 * time.Hour, 7 * 24 * time.Hour, 30 * 24 * time.Hour},
+		},
+		{
+			name:         "OK - configured thresholds are sorted",
+			cfg:          OPCUAConfig{CertificateCheckInterval: "10m", CertificateExpiryWarnings: []string{"24h", "1h", "72h"}},
+			wantInterval: 10 * time.Minute,
+			wantWarnings: []time.Duration{time.Hour, 24 * time.Hour, 72 * time.Hour},
+		},
+		{
+			name:    "NOK - invalid interval",
+			cfg:     OPCUAConfig{CertificateCheckInterval: "often"},
+			wantErr: true,
+		},
+		{
+			name:    "NOK - invalid threshold",
+			cfg:     OPCUAConfig{CertificateExpiryWarnings: []string{"-1h"}},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, err := NewCertificateMonitor(tt.cfg, logger.NewMockClient(), nil)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NewCertificateMonitor() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if m.interval != tt.wantInterval {
+				t.Errorf("interval = %s, want %s", m.interval, tt.wantInterval)
+			}
+			if len(m.warnings) != len(tt.wantWarnings) {
+				t.Fatalf("warnings = %v, want %v", m.warnings, tt.wantWarnings)
+			}
+			for i := range m.warnings {
+				if m.warnings[i] != tt.wantWarnings[i] {
+					t.Errorf("warnings = %v, want %v", m.warnings, tt.wantWarnings)
+				}
+			}
+		})
+	}
+}
+
+func TestCertificateMonitor_checkExpiry(t *testing.T) {
+	tests := []struct {
+		name       string
+		notAfter   time.Time
+		wantWarned time.Duration
+	}{
+		{
+			name:     "OK - not close to expiry",
+			notAfter: time.Now().Add(90 * 24 * time.Hour),
+		},
+		{
+			name:       "OK - within first threshold",
+			notAfter:   time.Now().Add(10 * 24 * time.Hour),
+			wantWarned: 30 * 24 * time.Hour,
+		},
+		{
+			name:       "OK - within last threshold",
+			notAfter:   time.Now().Add(time.Hour),
+			wantWarned: 24 * time.Hour,
+		},
+		{
+			name:     "OK - expired",
+			notAfter: time.Now().Add(-time.Minute),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			certFile, _ := test.CreateCertificate(t, t.TempDir(), tt.notAfter)
+			m, err := NewCertificateMonitor(OPCUAConfig{}, logger.NewMockClient(), nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			m.checkExpiry(certFile)
+
+			der, _ := loadCertificate(certFile)
+			if got := m.warned[thumbprint(der)]; got != tt.wantWarned {
+				t.Errorf("warned = %s, want %s", got, tt.wantWarned)
+			}
+		})
+	}
+}
+
+func TestServer_certificateRotated(t *testing.T) {
+	dir := t.TempDir()
+	certFile, keyFile := test.CreateCertificate(t, dir, time.Now().Add(time.Hour))
+	original, err := readApplicationCertificate(certFile, keyFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	newServer := func(t *testing.T) *Server {
+		s := NewServer("Test", test.NewDSMock(t), nil)
+		s.config = &Config{CertFile: certFile, KeyFile: keyFile}
+		s.certificate = original.Thumbprint
+		s.client = &Client{gopcuaMocks.NewMockClient(t), s.context.ctx}
+		return s
+	}
+
+	t.Run("OK - certificate unchanged", func(t *testing.T) {
+		if _, rotated := newServer(t).certificateRotated(); rotated {
+			t.Error("expected certificate not to be rotated")
+		}
+	})
+
+	t.Run("OK - not connected", func(t *testing.T) {
+		s := newServer(t)
+		s.client = nil
+		if _, rotated := s.certificateRotated(); rotated {
+			t.Error("expected certificate not to be rotated")
+		}
+	})
+
+	t.Run("OK - new certificate without matching key", func(t *testing.T) {
+		s := newServer(t)
+		otherCert, _ := test.CreateCertificate(t, t.TempDir(), time.Now().Add(time.Hour))
+		s.config.CertFile = otherCert
+		if _, rotated := s.certificateRotated(); rotated {
+			t.Error("expected mismatching key pair to be ignored")
+		}
+	})
+
+	t.Run("OK - new certificate and key", func(t *testing.T) {
+		s := newServer(t)
+		newDir := t.TempDir()
+		newCert, newKey := test.CreateCertificate(t, newDir, time.Now().Add(time.Hour))
+		s.config = &Config{CertFile: newCert, KeyFile: newKey}
+		if _, rotated := s.certificateRotated(); !rotated {
+			t.Error("expected certificate to be rotated")
+		}
+	})
+}
+
+func Test_ensureApplicationCertificate_renew(t *testing.T) {
+	tests := []struct {
+		name          string
+		notAfter      time.Time
+		warnings      []string
+		wantGenerated bool
+	}{
+		{
+			name:          "OK - expired certificate renewed",
+			notAfter:      time.Now().Add(-time.Minute),
+			wantGenerated: true,
+		},
+		{
+			name:          "OK - certificate within the smallest threshold renewed",
+			notAfter:      time.Now().Add(12 * time.Hour),
+			wantGenerated: true,
+		},
+		{
+			name:          "OK - certificate within a configured threshold renewed",
+			notAfter:      time.Now().Add(48 * time.Hour),
+			warnings:      []string{"720h", "72h"},
+			wantGenerated: true,
+		},
+		{
+			name:     "OK - certificate before the smallest threshold kept",
+			notAfter: time.Now().Add(48 * time.Hour),
+		},
+		{
+			name:     "OK - certificate within the largest threshold only kept",
+			notAfter: time.Now().Add(10 * 24 * time.Hour),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := OPCUAConfig{PKIDir: t.TempDir(), CertificateExpiryWarnings: tt.warnings}
+			certFile, keyFile := applicationCertificateFiles(cfg)
+			if err := os.MkdirAll(filepath.Dir(keyFile), 0700); err != nil {
+				t.Fatal(err)
+			}
+			storedCert, storedKey := test.CreateCertificate(t, t.TempDir(), tt.notAfter)
+			for src, dst := range map[string]string{storedCert: certFile, storedKey: keyFile} {
+				b, _ := os.ReadFile(src)
+				if err := os.WriteFile(dst, b, 0600); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			cert, generated, err := ensureApplicationCertificate(cfg)
+			if err != nil {
+				t.Fatalf("ensureApplicationCertificate() error = %v", err)
+			}
+			if generated != tt.wantGenerated {
+				t.Fatalf("ensureApplicationCertificate() generated = %v, want %v", generated, tt.wantGenerated)
+			}
+			if generated && !cert.NotAfter.After(tt.notAfter) {
+				t.Errorf("expected renewed certificate, got notAfter = %s", cert.NotAfter)
+			}
+		})
+	}
+}
+
+func Test_certificateRenewal(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     OPCUAConfig
+		want    time.Duration
+		wantErr bool
+	}{
+		{
+			name: "OK - smallest default threshold",
+			want: 24 * time.Hour,
+		},
+		{
+			name: "OK - smallest configured threshold",
+			cfg:  OPCUAConfig{CertificateExpiryWarnings: []string{"720h", "72h", "168h"}},
+			want: 72 * time.Hour,
+		},
+		{
+			name: "OK - half the validity of short lived certificates",
+			cfg:  OPCUAConfig{CertificateValidity: "12h"},
+			want: 6 * time.Hour,
+		},
+		{
+			name:    "NOK - invalid threshold",
+			cfg:     OPCUAConfig{CertificateExpiryWarnings: []string{"soon"}},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := certificateRenewal(tt.cfg)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("certificateRenewal() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("certificateRenewal() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
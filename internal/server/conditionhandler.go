@@ -0,0 +1,169 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2025 Schneider Electric
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package server
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"time"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/models"
+	"github.com/gopcua/opcua/id"
+	"github.com/gopcua/opcua/ua"
+)
+
+// Methods of the Alarms & Conditions model which can be called on a condition
+const (
+	ConditionAcknowledge   = "Acknowledge"
+	ConditionConfirm       = "Confirm"
+	ConditionAddComment    = "AddComment"
+	ConditionTimedShelve   = "TimedShelve"
+	ConditionOneShotShelve = "OneShotShelve"
+	ConditionUnshelve      = "Unshelve"
+	ConditionEnable        = "Enable"
+	ConditionDisable       = "Disable"
+)
+
+// shelvingStateName is the browse name of the shelving state machine of an alarm
+const shelvingStateName = "ShelvingState"
+
+type conditionMethod struct {
+	id       uint32 // method of the standard condition types
+	event    bool   // identified by the EventId of a notification, with a comment
+	shelving bool   // called on the shelving state machine of the alarm
+}
+
+var conditionMethods = map[string]conditionMethod{
+	ConditionAcknowledge:   {id: id.AcknowledgeableConditionType_Acknowledge, event: true},
+	ConditionConfirm:       {id: id.AcknowledgeableConditionType_Confirm, event: true},
+	ConditionAddComment:    {id: id.ConditionType_AddComment, event: true},
+	ConditionTimedShelve:   {id: id.ShelvedStateMachineType_TimedShelve, shelving: true},
+	ConditionOneShotShelve: {id: id.ShelvedStateMachineType_OneShotShelve, shelving: true},
+	ConditionUnshelve:      {id: id.ShelvedStateMachineType_Unshelve, shelving: true},
+	ConditionEnable:        {id: id.ConditionType_Enable},
+	ConditionDisable:       {id: id.ConditionType_Disable},
+}
+
+// ConditionCall is a call of a method of the Alarms & Conditions model on a condition,
+// identified by the ConditionId and EventId fields of a received event
+type ConditionCall struct {
+	Method       string
+	ConditionID  string
+	EventID      string // base64 encoded, as sent in the event readings
+	Comment      string
+	ShelvingTime time.Duration
+}
+
+// ProcessConditionCall calls the method on the condition. The errors reported by the server
+// wrap the ua.StatusCode of the call, invalid calls wrap ua.StatusBadInvalidArgument.
+func (s *Server) ProcessConditionCall(call ConditionCall) error {
+	device, err := s.sdk.GetDeviceByName(s.deviceName)
+	if err != nil {
+		return fmt.Errorf("device not found: %v", err)
+	}
+
+	if device.AdminState == models.Locked || device.OperatingState == models.Down {
+		return fmt.Errorf("condition method [%s] not processed for [%s]: device is locked or down", call.Method, s.deviceName)
+	}
+
+	method, ok := conditionMethods[call.Method]
+	if !ok {
+		return fmt.Errorf("[%s] unknown condition method %q: %w", s.deviceName, call.Method, ua.StatusBadInvalidArgument)
+	}
+
+	request, err := conditionMethodRequest(method, call)
+	if err != nil {
+		return fmt.Errorf("[%s] invalid %s call: %w", s.deviceName, call.Method, err)
+	}
+
+	client, err := s.connectedClient()
+	if err != nil {
+		return fmt.Errorf("[%s] client not initialized: %v", s.deviceName, err)
+	}
+
+	ctx, cancel := s.requestContext(client)
+	defer cancel()
+
+	if method.shelving {
+		if request.ObjectID, err = shelvingState(ctx, client, request.ObjectID); err != nil {
+			return fmt.Errorf("[%s] no shelving state for condition %s: %w", s.deviceName, call.ConditionID, err)
+		}
+	}
+
+	resp, err := client.Call(ctx, request)
+	if err != nil {
+		return fmt.Errorf("[%s] %s call failed: %w", s.deviceName, call.Method, err)
+	}
+	if resp.StatusCode != ua.StatusOK {
+		return fmt.Errorf("[%s] %s call on condition %s failed: %w", s.deviceName, call.Method, call.ConditionID, resp.StatusCode)
+	}
+
+	s.sdk.LoggingClient().Infof("[%s] %s called on condition %s", s.deviceName, call.Method, call.ConditionID)
+	return nil
+}
+
+// conditionMethodRequest builds the request calling the method on the condition
+func conditionMethodRequest(method conditionMethod, call ConditionCall) (*ua.CallMethodRequest, error) {
+	conditionID, err := ua.ParseNodeID(call.ConditionID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid ConditionId %q: %v: %w", call.ConditionID, err, ua.StatusBadInvalidArgument)
+	}
+
+	var inputs []*ua.Variant
+	switch {
+	case method.event:
+		eventID, err := base64.StdEncoding.DecodeString(call.EventID)
+		if err != nil || len(eventID) == 0 {
+			return nil, fmt.Errorf("invalid EventId %q: %w", call.EventID, ua.StatusBadInvalidArgument)
+		}
+		inputs = []*ua.Variant{ua.MustVariant(eventID), ua.MustVariant(ua.NewLocalizedText(call.Comment))}
+	case method.id == id.ShelvedStateMachineType_TimedShelve:
+		if call.ShelvingTime <= 0 {
+			return nil, fmt.Errorf("shelving time must be positive: %w", ua.StatusBadInvalidArgument)
+		}
+		inputs = []*ua.Variant{ua.MustVariant(float64(call.ShelvingTime.Milliseconds()))}
+	}
+
+	return &ua.CallMethodRequest{
+		ObjectID:       conditionID,
+		MethodID:       ua.NewNumericNodeID(0, method.id),
+		InputArguments: inputs,
+	}, nil
+}
+
+// shelvingState returns the node of the shelving state machine of the alarm, on which the shelving methods are called
+func shelvingState(ctx context.Context, client *Client, conditionID *ua.NodeID) (*ua.NodeID, error) {
+	req := &ua.TranslateBrowsePathsToNodeIDsRequest{BrowsePaths: []*ua.BrowsePath{{
+		StartingNode: conditionID,
+		RelativePath: &ua.RelativePath{Elements: []*ua.RelativePathElement{{
+			ReferenceTypeID: ua.NewNumericNodeID(0, id.HasComponent),
+			IncludeSubtypes: true,
+			TargetName:      &ua.QualifiedName{Name: shelvingStateName},
+		}}},
+	}}}
+
+	var shelving *ua.NodeID
+	err := client.Send(ctx, req, func(v ua.Response) error {
+		res, ok := v.(*ua.TranslateBrowsePathsToNodeIDsResponse)
+		if !ok {
+			return fmt.Errorf("unexpected response type %T", v)
+		}
+		if len(res.Results) != 1 {
+			return fmt.Errorf("unexpected number of results %d", len(res.Results))
+		}
+		if res.Results[0].StatusCode != ua.StatusOK {
+			return res.Results[0].StatusCode
+		}
+		if len(res.Results[0].Targets) == 0 || res.Results[0].Targets[0].TargetID == nil {
+			return ua.StatusBadNoMatch
+		}
+		shelving = res.Results[0].Targets[0].TargetID.NodeID
+		return nil
+	})
+	return shelving, err
+}
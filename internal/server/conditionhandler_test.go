@@ -0,0 +1,192 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2025 Schneider Electric
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package server
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/edgexfoundry/device-opcua-go/internal/test"
+	gopcuaMocks "github.com/edgexfoundry/device-opcua-go/pkg/gopcua/mocks"
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/models"
+	"github.com/gopcua/opcua"
+	"github.com/gopcua/opcua/id"
+	"github.com/gopcua/opcua/ua"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConditionMethodRequest(t *testing.T) {
+	tests := []struct {
+		name       string
+		call       ConditionCall
+		wantMethod uint32
+		wantInputs []any
+		wantErr    bool
+	}{
+		{
+			name:       "OK - acknowledge",
+			call:       ConditionCall{Method: ConditionAcknowledge, ConditionID: "ns=2;s=Boiler.HighTemp", EventID: "AQI=", Comment: "on it"},
+			wantMethod: id.AcknowledgeableConditionType_Acknowledge,
+			wantInputs: []any{[]byte{0x01, 0x02}, ua.NewLocalizedText("on it")},
+		},
+		{
+			name:       "OK - add comment without text",
+			call:       ConditionCall{Method: ConditionAddComment, ConditionID: "ns=2;s=Boiler.HighTemp", EventID: "AQI="},
+			wantMethod: id.ConditionType_AddComment,
+			wantInputs: []any{[]byte{0x01, 0x02}, ua.NewLocalizedText("")},
+		},
+		{
+			name:       "OK - timed shelve",
+			call:       ConditionCall{Method: ConditionTimedShelve, ConditionID: "ns=2;s=Boiler.HighTemp", ShelvingTime: time.Minute},
+			wantMethod: id.ShelvedStateMachineType_TimedShelve,
+			wantInputs: []any{float64(60000)},
+		},
+		{
+			name:       "OK - disable",
+			call:       ConditionCall{Method: ConditionDisable, ConditionID: "ns=2;s=Boiler.HighTemp"},
+			wantMethod: id.ConditionType_Disable,
+		},
+		{
+			name:    "NOK - invalid condition id",
+			call:    ConditionCall{Method: ConditionEnable, ConditionID: "ns=two;s=Boiler"},
+			wantErr: true,
+		},
+		{
+			name:    "NOK - missing event id",
+			call:    ConditionCall{Method: ConditionConfirm, ConditionID: "ns=2;s=Boiler.HighTemp"},
+			wantErr: true,
+		},
+		{
+			name:    "NOK - event id not base64",
+			call:    ConditionCall{Method: ConditionAcknowledge, ConditionID: "ns=2;s=Boiler.HighTemp", EventID: "not base64!"},
+			wantErr: true,
+		},
+		{
+			name:    "NOK - missing shelving time",
+			call:    ConditionCall{Method: ConditionTimedShelve, ConditionID: "ns=2;s=Boiler.HighTemp"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := conditionMethodRequest(conditionMethods[tt.call.Method], tt.call)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, ua.StatusBadInvalidArgument)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, ua.NewStringNodeID(2, "Boiler.HighTemp"), got.ObjectID)
+			assert.Equal(t, ua.NewNumericNodeID(0, tt.wantMethod), got.MethodID)
+			require.Len(t, got.InputArguments, len(tt.wantInputs))
+			for i, want := range tt.wantInputs {
+				assert.Equal(t, want, got.InputArguments[i].Value())
+			}
+		})
+	}
+}
+
+func TestServer_ProcessConditionCall(t *testing.T) {
+	okDevice := models.Device{Name: "test", AdminState: models.Unlocked, OperatingState: models.Up}
+	condition := ua.NewStringNodeID(2, "Boiler.HighTemp")
+	shelving := ua.NewStringNodeID(2, "Boiler.HighTemp.ShelvingState")
+
+	tests := []struct {
+		name       string
+		device     models.Device
+		call       ConditionCall
+		setup      func(client *gopcuaMocks.MockClient)
+		wantStatus ua.StatusCode
+		wantErr    bool
+	}{
+		{
+			name:    "NOK - device locked",
+			device:  models.Device{AdminState: models.Locked},
+			call:    ConditionCall{Method: ConditionEnable, ConditionID: condition.String()},
+			wantErr: true,
+		},
+		{
+			name:       "NOK - unknown method",
+			device:     okDevice,
+			call:       ConditionCall{Method: "Silence", ConditionID: condition.String()},
+			wantStatus: ua.StatusBadInvalidArgument,
+			wantErr:    true,
+		},
+		{
+			name:   "OK - acknowledge",
+			device: okDevice,
+			call:   ConditionCall{Method: ConditionAcknowledge, ConditionID: condition.String(), EventID: "AQI="},
+			setup: func(client *gopcuaMocks.MockClient) {
+				client.On("Call", test.RequestContext(), mock.MatchedBy(func(r *ua.CallMethodRequest) bool {
+					return r.ObjectID.String() == condition.String()
+				})).Return(&ua.CallMethodResult{StatusCode: ua.StatusOK}, nil)
+			},
+		},
+		{
+			name:   "NOK - event id unknown",
+			device: okDevice,
+			call:   ConditionCall{Method: ConditionAcknowledge, ConditionID: condition.String(), EventID: "AQI="},
+			setup: func(client *gopcuaMocks.MockClient) {
+				client.On("Call", test.RequestContext(), mock.Anything).Return(&ua.CallMethodResult{StatusCode: ua.StatusBadEventIDUnknown}, nil)
+			},
+			wantStatus: ua.StatusBadEventIDUnknown,
+			wantErr:    true,
+		},
+		{
+			name:   "OK - unshelve on the shelving state",
+			device: okDevice,
+			call:   ConditionCall{Method: ConditionUnshelve, ConditionID: condition.String()},
+			setup: func(client *gopcuaMocks.MockClient) {
+				mockSend(client, mock.AnythingOfType("*ua.TranslateBrowsePathsToNodeIDsRequest"), &ua.TranslateBrowsePathsToNodeIDsResponse{
+					Results: []*ua.BrowsePathResult{{StatusCode: ua.StatusOK, Targets: []*ua.BrowsePathTarget{{TargetID: ua.NewExpandedNodeID(shelving, "", 0)}}}},
+				}, nil)
+				client.On("Call", test.RequestContext(), mock.MatchedBy(func(r *ua.CallMethodRequest) bool {
+					return r.ObjectID.String() == shelving.String()
+				})).Return(&ua.CallMethodResult{StatusCode: ua.StatusOK}, nil)
+			},
+		},
+		{
+			name:   "NOK - condition is not an alarm",
+			device: okDevice,
+			call:   ConditionCall{Method: ConditionTimedShelve, ConditionID: condition.String(), ShelvingTime: time.Hour},
+			setup: func(client *gopcuaMocks.MockClient) {
+				mockSend(client, mock.AnythingOfType("*ua.TranslateBrowsePathsToNodeIDsRequest"), &ua.TranslateBrowsePathsToNodeIDsResponse{
+					Results: []*ua.BrowsePathResult{{StatusCode: ua.StatusBadNoMatch}},
+				}, nil)
+			},
+			wantStatus: ua.StatusBadNoMatch,
+			wantErr:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dsMock := test.NewDSMock(t)
+			s := NewServer("test", dsMock, nil)
+			clientMock := gopcuaMocks.NewMockClient(t)
+			s.client = &Client{clientMock, s.context.ctx}
+			dsMock.On("GetDeviceByName", "test").Return(tt.device, nil)
+			if tt.setup != nil {
+				clientMock.On("State").Return(opcua.Connected)
+				tt.setup(clientMock)
+			}
+
+			err := s.ProcessConditionCall(tt.call)
+			if !tt.wantErr {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			if tt.wantStatus != 0 {
+				var status ua.StatusCode
+				require.True(t, errors.As(err, &status))
+				assert.Equal(t, tt.wantStatus, status)
+			}
+		})
+	}
+}
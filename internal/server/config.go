@@ -8,19 +8,80 @@ package server
 
 import (
 	"encoding/json"
+	"fmt"
+	"slices"
+	"time"
 
 	"github.com/edgexfoundry/go-mod-core-contracts/v4/models"
 	"github.com/go-playground/validator/v10"
+	"github.com/gopcua/opcua"
+)
+
+const (
+	defaultRequestTimeout        = 10 * time.Second
+	defaultSessionTimeout        = 20 * time.Minute
+	defaultSecureChannelLifetime = time.Hour
+	defaultConnectTimeout        = 10 * time.Second
+	defaultHealthCheckInterval   = 10 * time.Second
+	defaultHealthCheckFailures   = 3
+	defaultPublishingInterval    = 500 * time.Millisecond
+	defaultReadMaxAge            = 2 * time.Second
 )
 
 // Config struct details for OPCUA device list protocol properties
 type Config struct {
-	Endpoint  string   `json:"Endpoint" validate:"required"`
-	Policy    string   `json:"Policy" validate:"oneof=None Basic128Rsa15 Basic256 Basic256Sha256 Aes128Sha256RsaOaep Aes256Sha256RsaPss"`
-	Mode      string   `json:"Mode" validate:"oneof=None Sign SignAndEncrypt"`
-	CertFile  string   `json:"CertFile" validate:"required_unless=Policy None Mode None"`
-	KeyFile   string   `json:"KeyFile" validate:"required_unless=Policy None Mode None"`
+	Endpoint  string   `json:"Endpoint" validate:"required_without=Endpoints"`
+	Policy    string   `json:"Policy" validate:"oneof=None Basic128Rsa15 Basic256 Basic256Sha256 Aes128Sha256RsaOaep Aes256Sha256RsaPss Auto"`
+	Mode      string   `json:"Mode" validate:"oneof=None Sign SignAndEncrypt Auto"`
+	CertFile  string   `json:"CertFile" validate:"required_with=KeyFile"`
+	KeyFile   string   `json:"KeyFile" validate:"required_with=CertFile"`
 	Resources []string `json:"Resources"`
+	// Endpoints lists the redundant servers of the device, tried in order after Endpoint
+	Endpoints []string `json:"Endpoints" validate:"omitempty,dive,required"`
+	// MinServiceLevel is the lowest ServiceLevel accepted from a server, 0 disables the check
+	MinServiceLevel int `json:"MinServiceLevel" validate:"min=0,max=255"`
+	// RequestTimeout bounds every request, SessionTimeout and SecureChannelLifetime are requested from
+	// the server, and ConnectTimeout bounds the connection. Durations such as 10s, defaults when empty
+	RequestTimeout        string `json:"RequestTimeout" validate:"omitempty,duration"`
+	SessionTimeout        string `json:"SessionTimeout" validate:"omitempty,duration"`
+	SecureChannelLifetime string `json:"SecureChannelLifetime" validate:"omitempty,duration"`
+	ConnectTimeout        string `json:"ConnectTimeout" validate:"omitempty,duration"`
+	// ReverseConnect waits for the server to open the connection to the service, see OPCUAConfig.ReverseConnectAddress
+	ReverseConnect bool `json:"ReverseConnect"`
+	// ServerURI identifies the server opening reverse connections, which are matched by Endpoint when empty
+	ServerURI string `json:"ServerURI"`
+	// MinSecurityLevel is the lowest endpoint security level accepted, as advertised by the server
+	MinSecurityLevel int `json:"MinSecurityLevel" validate:"min=0,max=255"`
+	// AuthMode selects the user identity used to activate the session. Default: Anonymous
+	AuthMode string `json:"AuthMode" validate:"omitempty,oneof=Anonymous UserName Certificate Issued"`
+	// SecretName references the secret holding the user or OAuth2 client credentials in the secret store
+	SecretName string `json:"SecretName" validate:"required_if=AuthMode UserName,required_if=AuthMode Issued"`
+	// UserCertFile and UserKeyFile hold the X.509 user identity, distinct from the application instance certificate
+	UserCertFile string `json:"UserCertFile" validate:"required_if=AuthMode Certificate"`
+	UserKeyFile  string `json:"UserKeyFile" validate:"required_if=AuthMode Certificate"`
+	// TokenEndpoint is the OAuth2 token endpoint of the identity provider issuing user tokens
+	TokenEndpoint string `json:"TokenEndpoint" validate:"required_if=AuthMode Issued,omitempty,url"`
+	TokenScope    string `json:"TokenScope"`
+	// HealthCheckInterval is how often the server state is read, and HealthCheckFailures how many
+	// consecutive failed checks set the device down. Defaults when empty or 0
+	HealthCheckInterval string `json:"HealthCheckInterval" validate:"omitempty,duration"`
+	HealthCheckFailures int    `json:"HealthCheckFailures" validate:"min=0"`
+	// PublishingInterval, LifetimeCount, MaxKeepAliveCount, MaxNotificationsPerPublish and Priority
+	// are requested for the subscription, which the server may revise. Library defaults when empty or 0
+	PublishingInterval         string `json:"PublishingInterval" validate:"omitempty,duration"`
+	LifetimeCount              int    `json:"LifetimeCount" validate:"min=0,max=4294967295"`
+	MaxKeepAliveCount          int    `json:"MaxKeepAliveCount" validate:"min=0,max=4294967295"`
+	MaxNotificationsPerPublish int    `json:"MaxNotificationsPerPublish" validate:"min=0,max=4294967295"`
+	Priority                   int    `json:"Priority" validate:"min=0,max=255"`
+	// Timestamp selects the clock setting the Origin of the readings, the others are sent as
+	// reading tags. Resources may override it with their timestamp attribute. Default: Local
+	Timestamp string `json:"Timestamp" validate:"omitempty,oneof=Source Server Local"`
+	// Quality selects the values sent by the severity of their status code: KeepUncertain, or DropBad,
+	// sends Good and Uncertain values, and KeepAll every value. Default: KeepUncertain
+	Quality string `json:"Quality" validate:"omitempty,oneof=KeepUncertain DropBad KeepAll"`
+	// ReadMaxAge is the oldest cached value the server may return to a read, 0 reads from the
+	// device. Resources may override it with their maxAge attribute. Default: 2s
+	ReadMaxAge string `json:"ReadMaxAge" validate:"omitempty,nonnegduration"`
 }
 
 // NewConfig converts a properties map to a Config struct
@@ -37,8 +98,120 @@ func NewConfig(props models.ProtocolProperties) (*Config, error) {
 	return c, nil
 }
 
+// EndpointURLs returns the configured endpoints in order of preference
+func (c *Config) EndpointURLs() []string {
+	urls := make([]string, 0, len(c.Endpoints)+1)
+	if c.Endpoint != "" {
+		urls = append(urls, c.Endpoint)
+	}
+	for _, url := range c.Endpoints {
+		if !slices.Contains(urls, url) {
+			urls = append(urls, url)
+		}
+	}
+	return urls
+}
+
+// RequiresCertificate reports whether an application certificate is needed for the secure channel
+func (c *Config) RequiresCertificate() bool {
+	return (c.Policy != "" && c.Policy != "None") || (c.Mode != "" && c.Mode != "None")
+}
+
+// RequestTimeoutDuration returns how long a request to the server may take
+func (c *Config) RequestTimeoutDuration() time.Duration {
+	return parseDuration(c.RequestTimeout, defaultRequestTimeout)
+}
+
+// SessionTimeoutDuration returns the session timeout requested from the server
+func (c *Config) SessionTimeoutDuration() time.Duration {
+	return parseDuration(c.SessionTimeout, defaultSessionTimeout)
+}
+
+// SecureChannelLifetimeDuration returns the secure channel lifetime requested from the server
+func (c *Config) SecureChannelLifetimeDuration() time.Duration {
+	return parseDuration(c.SecureChannelLifetime, defaultSecureChannelLifetime)
+}
+
+// ConnectTimeoutDuration returns how long establishing the connection may take
+func (c *Config) ConnectTimeoutDuration() time.Duration {
+	return parseDuration(c.ConnectTimeout, defaultConnectTimeout)
+}
+
+// ReadMaxAgeDuration returns the maximum age of the values returned to reads, which may be 0
+func (c *Config) ReadMaxAgeDuration() time.Duration {
+	d, err := time.ParseDuration(c.ReadMaxAge)
+	if err != nil || d < 0 {
+		return defaultReadMaxAge
+	}
+	return d
+}
+
+// HealthCheckIntervalDuration returns how often the health of the server is checked
+func (c *Config) HealthCheckIntervalDuration() time.Duration {
+	return parseDuration(c.HealthCheckInterval, defaultHealthCheckInterval)
+}
+
+// HealthCheckFailureThreshold returns how many consecutive failed health checks set the device down
+func (c *Config) HealthCheckFailureThreshold() int {
+	if c.HealthCheckFailures <= 0 {
+		return defaultHealthCheckFailures
+	}
+	return c.HealthCheckFailures
+}
+
+// SubscriptionParameters returns the parameters requested for the subscription of the device
+func (c *Config) SubscriptionParameters() opcua.SubscriptionParameters {
+	return opcua.SubscriptionParameters{
+		Interval:                   parseDuration(c.PublishingInterval, defaultPublishingInterval),
+		LifetimeCount:              uint32(c.LifetimeCount),
+		MaxKeepAliveCount:          uint32(c.MaxKeepAliveCount),
+		MaxNotificationsPerPublish: uint32(c.MaxNotificationsPerPublish),
+		Priority:                   uint8(c.Priority),
+	}
+}
+
+func parseDuration(value string, defaultValue time.Duration) time.Duration {
+	d, err := time.ParseDuration(value)
+	if err != nil || d <= 0 {
+		return defaultValue
+	}
+	return d
+}
+
 // Validate makes sure the connection properties are valid
 func Validate(cfg *Config) error {
 	validate := validator.New()
-	return validate.Struct(cfg)
+	if err := validate.RegisterValidation("duration", validateDuration); err != nil {
+		return err
+	}
+	if err := validate.RegisterValidation("nonnegduration", validateNonNegativeDuration); err != nil {
+		return err
+	}
+	if err := validate.Struct(cfg); err != nil {
+		return err
+	}
+	// Auto never selects None, and None policies and modes only go together
+	if cfg.Mode == SecurityAuto && (cfg.Policy == "" || cfg.Policy == "None") {
+		return fmt.Errorf("security mode Auto requires a security policy other than None, use mode None without security")
+	}
+	if cfg.Policy == SecurityAuto && (cfg.Mode == "" || cfg.Mode == "None") {
+		return fmt.Errorf("security policy Auto requires a security mode other than None, use policy None without security")
+	}
+	// the subscription must outlive at least three keep-alive intervals, OPC UA Part 4 5.13.2
+	if cfg.LifetimeCount > 0 && cfg.MaxKeepAliveCount > 0 && cfg.LifetimeCount < 3*cfg.MaxKeepAliveCount {
+		return fmt.Errorf("LifetimeCount %d must be at least three times MaxKeepAliveCount %d", cfg.LifetimeCount, cfg.MaxKeepAliveCount)
+	}
+	return nil
+}
+
+// validateDuration accepts positive durations such as 500ms or 1m30s
+func validateDuration(fl validator.FieldLevel) bool {
+	d, err := time.ParseDuration(fl.Field().String())
+	return err == nil && d > 0
+}
+
+// validateNonNegativeDuration accepts durations which may be 0, such as 0 or 2s
+func validateNonNegativeDuration(fl validator.FieldLevel) bool {
+	d, err := time.ParseDuration(fl.Field().String())
+	return err == nil && d >= 0
 }
@@ -9,9 +9,12 @@ package server
 import (
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/edgexfoundry/device-opcua-go/internal/test"
 	"github.com/edgexfoundry/go-mod-core-contracts/v4/models"
+	"github.com/gopcua/opcua"
+	"github.com/stretchr/testify/assert"
 )
 
 func TestNewConfig(t *testing.T) {
@@ -66,14 +69,216 @@ func TestValidate(t *testing.T) {
 			wantErr: true,
 		},
 		{
-			name: "NOK - missing certfile or keyfile",
+			name: "OK - redundant endpoints without primary endpoint",
+			cfg: &Config{
+				Endpoints: []string{test.Address, "opc.tcp://backup"},
+				Policy:    "None",
+				Mode:      "None",
+			},
+		},
+		{
+			name: "NOK - empty redundant endpoint",
+			cfg: &Config{
+				Endpoints: []string{""},
+				Policy:    "None",
+				Mode:      "None",
+			},
+			wantErr: true,
+		},
+		{
+			name: "OK - source timestamp",
+			cfg: &Config{
+				Endpoint:  test.Address,
+				Policy:    "None",
+				Mode:      "None",
+				Timestamp: TimestampSource,
+			},
+		},
+		{
+			name: "NOK - unknown quality policy",
+			cfg: &Config{
+				Endpoint: test.Address,
+				Policy:   "None",
+				Mode:     "None",
+				Quality:  "KeepGood",
+			},
+			wantErr: true,
+		},
+		{
+			name: "NOK - unknown timestamp",
+			cfg: &Config{
+				Endpoint:  test.Address,
+				Policy:    "None",
+				Mode:      "None",
+				Timestamp: "Device",
+			},
+			wantErr: true,
+		},
+		{
+			name: "OK - timeouts",
+			cfg: &Config{
+				Endpoint:              test.Address,
+				Policy:                "None",
+				Mode:                  "None",
+				RequestTimeout:        "5s",
+				SessionTimeout:        "30m",
+				SecureChannelLifetime: "2h",
+				ConnectTimeout:        "1500ms",
+			},
+		},
+		{
+			name: "NOK - invalid request timeout",
+			cfg: &Config{
+				Endpoint:       test.Address,
+				Policy:         "None",
+				Mode:           "None",
+				RequestTimeout: "5",
+			},
+			wantErr: true,
+		},
+		{
+			name: "NOK - negative connect timeout",
+			cfg: &Config{
+				Endpoint:       test.Address,
+				Policy:         "None",
+				Mode:           "None",
+				ConnectTimeout: "-1s",
+			},
+			wantErr: true,
+		},
+		{
+			name: "OK - automatic security selection",
+			cfg: &Config{
+				Endpoint:         test.Address,
+				Policy:           SecurityAuto,
+				Mode:             SecurityAuto,
+				MinSecurityLevel: 2,
+			},
+		},
+		{
+			name: "NOK - invalid minimum security level",
+			cfg: &Config{
+				Endpoint:         test.Address,
+				Policy:           "None",
+				Mode:             "None",
+				MinSecurityLevel: 256,
+			},
+			wantErr: true,
+		},
+		{
+			name: "NOK - certfile without keyfile",
+			cfg: &Config{
+				Endpoint: test.Address,
+				Policy:   "Basic256",
+				Mode:     "Sign",
+				CertFile: "cert.pem",
+			},
+			wantErr: true,
+		},
+		{
+			name: "OK - generated application certificate",
 			cfg: &Config{
 				Endpoint: test.Address,
 				Policy:   "Basic256",
 				Mode:     "Sign",
 			},
+		},
+		{
+			name: "NOK - username auth without secret name",
+			cfg: &Config{
+				Endpoint: test.Address, Policy: "None", Mode: "None", AuthMode: AuthModeUserName},
+			wantErr: true,
+		},
+		{
+			name: "NOK - invalid auth mode",
+			cfg: &Config{
+				Endpoint: test.Address, Policy: "None", Mode: "None", AuthMode: "Kerberos"},
+			wantErr: true,
+		},
+		{
+			name: "NOK - certificate auth without user certificate",
+			cfg: &Config{
+				Endpoint: test.Address, Policy: "None", Mode: "None", AuthMode: AuthModeCertificate},
+			wantErr: true,
+		},
+		{
+			name: "OK - certificate auth with user certificate",
+			cfg: &Config{
+				Endpoint: test.Address, Policy: "None", Mode: "None", AuthMode: AuthModeCertificate, UserCertFile: "cert.pem", UserKeyFile: "key.pem"},
+		},
+		{
+			name: "NOK - issued auth without token endpoint",
+			cfg: &Config{
+				Endpoint: test.Address, Policy: "None", Mode: "None", AuthMode: AuthModeIssued, SecretName: "opcua-idp"},
+			wantErr: true,
+		},
+		{
+			name: "NOK - issued auth without secret name",
+			cfg: &Config{
+				Endpoint: test.Address, Policy: "None", Mode: "None", AuthMode: AuthModeIssued, TokenEndpoint: "https://idp/token"},
+			wantErr: true,
+		},
+		{
+			name: "OK - issued auth",
+			cfg: &Config{
+				Endpoint: test.Address, Policy: "None", Mode: "None", AuthMode: AuthModeIssued, SecretName: "opcua-idp", TokenEndpoint: "https://idp/token"},
+		},
+		{
+			name: "OK - username auth with secret name",
+			cfg: &Config{
+				Endpoint: test.Address, Policy: "None", Mode: "None", AuthMode: AuthModeUserName, SecretName: "opcua-user"},
+		},
+		{
+			name: "OK - read from the device",
+			cfg:  &Config{Endpoint: test.Address, Policy: "None", Mode: "None", ReadMaxAge: "0s"},
+		},
+		{
+			name: "OK - read max age without unit",
+			cfg:  &Config{Endpoint: test.Address, Policy: "None", Mode: "None", ReadMaxAge: "0"},
+		},
+		{
+			name:    "NOK - negative read max age",
+			cfg:     &Config{Endpoint: test.Address, Policy: "None", Mode: "None", ReadMaxAge: "-1s"},
+			wantErr: true,
+		},
+		{
+			name: "NOK - invalid publishing interval",
+			cfg: &Config{
+				Endpoint: test.Address, Policy: "None", Mode: "None", PublishingInterval: "fast"},
+			wantErr: true,
+		},
+		{
+			name: "NOK - priority out of range",
+			cfg: &Config{
+				Endpoint: test.Address, Policy: "None", Mode: "None", Priority: 256},
+			wantErr: true,
+		},
+		{
+			name:    "NOK - auto mode without security policy",
+			cfg:     &Config{Endpoint: test.Address, Policy: "None", Mode: "Auto"},
+			wantErr: true,
+		},
+		{
+			name:    "NOK - auto policy without security mode",
+			cfg:     &Config{Endpoint: test.Address, Policy: "Auto", Mode: "None"},
 			wantErr: true,
 		},
+		{
+			name: "OK - auto policy and mode",
+			cfg:  &Config{Endpoint: test.Address, Policy: "Auto", Mode: "Auto", CertFile: "cert.pem", KeyFile: "key.pem"},
+		},
+		{
+			name: "NOK - lifetime count below three keep-alive counts",
+			cfg: &Config{
+				Endpoint: test.Address, Policy: "None", Mode: "None", LifetimeCount: 20, MaxKeepAliveCount: 10},
+			wantErr: true,
+		},
+		{
+			name: "OK - subscription parameters",
+			cfg: &Config{
+				Endpoint: test.Address, Policy: "None", Mode: "None", PublishingInterval: "5s", LifetimeCount: 30,
+				MaxKeepAliveCount: 10, MaxNotificationsPerPublish: 100, Priority: 200},
+		},
 		{
 			name: "OK - endpoint and resources",
 			cfg: &Config{
@@ -88,3 +293,76 @@ func TestValidate(t *testing.T) {
 		})
 	}
 }
+
+func TestConfig_SubscriptionParameters(t *testing.T) {
+	t.Run("defaults", func(t *testing.T) {
+		assert.Equal(t, opcua.SubscriptionParameters{Interval: defaultPublishingInterval}, (&Config{}).SubscriptionParameters())
+	})
+
+	t.Run("configured", func(t *testing.T) {
+		cfg := &Config{PublishingInterval: "1m", LifetimeCount: 30, MaxKeepAliveCount: 10, MaxNotificationsPerPublish: 100, Priority: 200}
+		assert.Equal(t, opcua.SubscriptionParameters{
+			Interval: time.Minute, LifetimeCount: 30, MaxKeepAliveCount: 10, MaxNotificationsPerPublish: 100, Priority: 200,
+		}, cfg.SubscriptionParameters())
+	})
+}
+
+func TestConfig_EndpointURLs(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  *Config
+		want []string
+	}{
+		{
+			name: "OK - single endpoint",
+			cfg:  &Config{Endpoint: test.Address},
+			want: []string{test.Address},
+		},
+		{
+			name: "OK - primary endpoint first, without duplicates",
+			cfg:  &Config{Endpoint: test.Address, Endpoints: []string{"opc.tcp://backup", test.Address}},
+			want: []string{test.Address, "opc.tcp://backup"},
+		},
+		{
+			name: "OK - redundant endpoints only",
+			cfg:  &Config{Endpoints: []string{"opc.tcp://a", "opc.tcp://b"}},
+			want: []string{"opc.tcp://a", "opc.tcp://b"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.cfg.EndpointURLs(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("EndpointURLs() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestConfig_timeouts(t *testing.T) {
+	t.Run("defaults", func(t *testing.T) {
+		cfg := &Config{}
+		assert.Equal(t, defaultRequestTimeout, cfg.RequestTimeoutDuration())
+		assert.Equal(t, defaultSessionTimeout, cfg.SessionTimeoutDuration())
+		assert.Equal(t, defaultSecureChannelLifetime, cfg.SecureChannelLifetimeDuration())
+		assert.Equal(t, defaultConnectTimeout, cfg.ConnectTimeoutDuration())
+		assert.Equal(t, defaultHealthCheckInterval, cfg.HealthCheckIntervalDuration())
+		assert.Equal(t, defaultHealthCheckFailures, cfg.HealthCheckFailureThreshold())
+		assert.Equal(t, defaultReadMaxAge, cfg.ReadMaxAgeDuration())
+	})
+
+	t.Run("configured", func(t *testing.T) {
+		cfg := &Config{RequestTimeout: "5s", SessionTimeout: "30m", SecureChannelLifetime: "2h", ConnectTimeout: "1500ms",
+			HealthCheckInterval: "30s", HealthCheckFailures: 5}
+		assert.Equal(t, 5*time.Second, cfg.RequestTimeoutDuration())
+		assert.Equal(t, 30*time.Minute, cfg.SessionTimeoutDuration())
+		assert.Equal(t, 2*time.Hour, cfg.SecureChannelLifetimeDuration())
+		assert.Equal(t, 1500*time.Millisecond, cfg.ConnectTimeoutDuration())
+		assert.Equal(t, 30*time.Second, cfg.HealthCheckIntervalDuration())
+		assert.Equal(t, 5, cfg.HealthCheckFailureThreshold())
+	})
+
+	t.Run("read from device", func(t *testing.T) {
+		cfg := &Config{ReadMaxAge: "0s"}
+		assert.Equal(t, time.Duration(0), cfg.ReadMaxAgeDuration())
+	})
+}
@@ -0,0 +1,94 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2025 Schneider Electric
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package server
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/gopcua/opcua"
+	"github.com/gopcua/opcua/ua"
+)
+
+// SecurityAuto selects the strongest endpoint offered by the server, for Config.Policy and Config.Mode
+const SecurityAuto = "Auto"
+
+// securityPolicyStrength ranks the supported security policies, when endpoints share the same security level
+var securityPolicyStrength = map[string]int{
+	ua.SecurityPolicyURIBasic128Rsa15:       1,
+	ua.SecurityPolicyURIBasic256:            2,
+	ua.SecurityPolicyURIBasic256Sha256:      3,
+	ua.SecurityPolicyURIAes128Sha256RsaOaep: 4,
+	ua.SecurityPolicyURIAes256Sha256RsaPss:  5,
+}
+
+// selectEndpoint picks the endpoint matching the configured security policy and mode.
+// With Auto, the endpoints are ranked by the security level advertised by the server.
+func (s *Server) selectEndpoint(endpoints []*ua.EndpointDescription) (*ua.EndpointDescription, error) {
+	if s.config.Policy != SecurityAuto && s.config.Mode != SecurityAuto {
+		ep, err := opcua.SelectEndpoint(endpoints, s.config.Policy, ua.MessageSecurityModeFromString(s.config.Mode))
+		if err != nil {
+			return nil, err
+		}
+		if ep.SecurityLevel < uint8(s.config.MinSecurityLevel) {
+			return nil, fmt.Errorf("endpoint %s (%s, %s) security level %d is below the minimum %d",
+				ep.EndpointURL, ep.SecurityPolicyURI, ep.SecurityMode, ep.SecurityLevel, s.config.MinSecurityLevel)
+		}
+		return ep, nil
+	}
+
+	tokenType := s.userTokenType()
+	candidates := make([]*ua.EndpointDescription, 0, len(endpoints))
+	for _, ep := range endpoints {
+		if s.config.Policy == SecurityAuto {
+			// Auto never downgrades to None, which has to be configured explicitly
+			if _, ok := securityPolicyStrength[ep.SecurityPolicyURI]; !ok {
+				continue
+			}
+		} else if ep.SecurityPolicyURI != ua.FormatSecurityPolicyURI(s.config.Policy) {
+			continue
+		}
+
+		if s.config.Mode == SecurityAuto {
+			if ep.SecurityMode != ua.MessageSecurityModeSign && ep.SecurityMode != ua.MessageSecurityModeSignAndEncrypt {
+				continue
+			}
+		} else if ep.SecurityMode != ua.MessageSecurityModeFromString(s.config.Mode) {
+			continue
+		}
+
+		if ep.SecurityLevel < uint8(s.config.MinSecurityLevel) {
+			continue
+		}
+		// the endpoint has to accept the user identity we have credentials for
+		if _, err := userTokenPolicy(ep, tokenType); err != nil {
+			continue
+		}
+		candidates = append(candidates, ep)
+	}
+
+	if len(candidates) == 0 {
+		return nil, fmt.Errorf("no endpoint matches security policy %s, mode %s, minimum security level %d and %s user token",
+			s.config.Policy, s.config.Mode, s.config.MinSecurityLevel, tokenType)
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		a, b := candidates[i], candidates[j]
+		if a.SecurityLevel != b.SecurityLevel {
+			return a.SecurityLevel > b.SecurityLevel
+		}
+		if securityPolicyStrength[a.SecurityPolicyURI] != securityPolicyStrength[b.SecurityPolicyURI] {
+			return securityPolicyStrength[a.SecurityPolicyURI] > securityPolicyStrength[b.SecurityPolicyURI]
+		}
+		return a.SecurityMode > b.SecurityMode
+	})
+
+	ep := candidates[0]
+	s.sdk.LoggingClient().Infof("[%s] selected endpoint %s, security policy %s, mode %s, security level %d",
+		s.deviceName, ep.EndpointURL, ep.SecurityPolicyURI, ep.SecurityMode, ep.SecurityLevel)
+	return ep, nil
+}
@@ -0,0 +1,126 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2025 Schneider Electric
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package server
+
+import (
+	"testing"
+
+	"github.com/edgexfoundry/device-opcua-go/internal/test"
+	"github.com/gopcua/opcua/ua"
+)
+
+func newEndpoint(policy string, mode ua.MessageSecurityMode, level uint8, tokenTypes ...ua.UserTokenType) *ua.EndpointDescription {
+	ep := &ua.EndpointDescription{
+		EndpointURL:       test.Address,
+		SecurityPolicyURI: policy,
+		SecurityMode:      mode,
+		SecurityLevel:     level,
+	}
+	if len(tokenTypes) == 0 {
+		tokenTypes = []ua.UserTokenType{ua.UserTokenTypeAnonymous}
+	}
+	for _, tokenType := range tokenTypes {
+		ep.UserIdentityTokens = append(ep.UserIdentityTokens, &ua.UserTokenPolicy{TokenType: tokenType})
+	}
+	return ep
+}
+
+func TestServer_selectEndpoint(t *testing.T) {
+	none := newEndpoint(ua.SecurityPolicyURINone, ua.MessageSecurityModeNone, 0)
+	basic256Sign := newEndpoint(ua.SecurityPolicyURIBasic256, ua.MessageSecurityModeSign, 2)
+	basic256Sha256Sign := newEndpoint(ua.SecurityPolicyURIBasic256Sha256, ua.MessageSecurityModeSign, 3, ua.UserTokenTypeAnonymous, ua.UserTokenTypeUserName)
+	basic256Sha256Encrypt := newEndpoint(ua.SecurityPolicyURIBasic256Sha256, ua.MessageSecurityModeSignAndEncrypt, 3)
+	aes256Encrypt := newEndpoint(ua.SecurityPolicyURIAes256Sha256RsaPss, ua.MessageSecurityModeSignAndEncrypt, 1)
+
+	endpoints := []*ua.EndpointDescription{none, basic256Sign, basic256Sha256Sign, basic256Sha256Encrypt, aes256Encrypt}
+
+	tests := []struct {
+		name      string
+		config    *Config
+		endpoints []*ua.EndpointDescription
+		want      *ua.EndpointDescription
+		wantErr   bool
+	}{
+		{
+			name:      "OK - explicit policy and mode",
+			config:    &Config{Policy: "Basic256", Mode: "Sign"},
+			endpoints: endpoints,
+			want:      basic256Sign,
+		},
+		{
+			name:      "OK - explicit None",
+			config:    &Config{Policy: "None", Mode: "None"},
+			endpoints: endpoints,
+			want:      none,
+		},
+		{
+			name:      "NOK - explicit endpoint below minimum security level",
+			config:    &Config{Policy: "None", Mode: "None", MinSecurityLevel: 1},
+			endpoints: endpoints,
+			wantErr:   true,
+		},
+		{
+			name:      "OK - highest security level, then strongest mode",
+			config:    &Config{Policy: SecurityAuto, Mode: SecurityAuto},
+			endpoints: endpoints,
+			want:      basic256Sha256Encrypt,
+		},
+		{
+			name:      "OK - strongest policy for the same security level",
+			config:    &Config{Policy: SecurityAuto, Mode: SecurityAuto},
+			endpoints: []*ua.EndpointDescription{basic256Sign, newEndpoint(ua.SecurityPolicyURIAes128Sha256RsaOaep, ua.MessageSecurityModeSign, 2)},
+			want:      newEndpoint(ua.SecurityPolicyURIAes128Sha256RsaOaep, ua.MessageSecurityModeSign, 2),
+		},
+		{
+			name:      "OK - auto policy with fixed mode",
+			config:    &Config{Policy: SecurityAuto, Mode: "Sign"},
+			endpoints: endpoints,
+			want:      basic256Sha256Sign,
+		},
+		{
+			name:      "OK - auto mode with fixed policy",
+			config:    &Config{Policy: "Aes256Sha256RsaPss", Mode: SecurityAuto},
+			endpoints: endpoints,
+			want:      aes256Encrypt,
+		},
+		{
+			name:      "OK - endpoint offering the user token",
+			config:    &Config{Policy: SecurityAuto, Mode: SecurityAuto, AuthMode: AuthModeUserName},
+			endpoints: endpoints,
+			want:      basic256Sha256Sign,
+		},
+		{
+			name:      "NOK - no downgrade to None",
+			config:    &Config{Policy: SecurityAuto, Mode: SecurityAuto},
+			endpoints: []*ua.EndpointDescription{none},
+			wantErr:   true,
+		},
+		{
+			name:      "NOK - below minimum security level",
+			config:    &Config{Policy: SecurityAuto, Mode: SecurityAuto, MinSecurityLevel: 4},
+			endpoints: endpoints,
+			wantErr:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewServer("Test", test.NewDSMock(t), nil)
+			s.config = tt.config
+
+			got, err := s.selectEndpoint(tt.endpoints)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("selectEndpoint() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if got.SecurityPolicyURI != tt.want.SecurityPolicyURI || got.SecurityMode != tt.want.SecurityMode {
+				t.Errorf("selectEndpoint() = %s %s, want %s %s", got.SecurityPolicyURI, got.SecurityMode, tt.want.SecurityPolicyURI, tt.want.SecurityMode)
+			}
+		})
+	}
+}
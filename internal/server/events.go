@@ -0,0 +1,272 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2025 Schneider Electric
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package server
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/gopcua/opcua/id"
+	"github.com/gopcua/opcua/ua"
+	"github.com/spf13/cast"
+)
+
+// EventFieldConditionID selects the node id of the condition which raised an event
+const EventFieldConditionID = "ConditionId"
+
+// EventFieldRefresh is added to the readings of a ConditionRefresh, which are the snapshot
+// of the retained conditions enclosed by a RefreshStart and a RefreshEnd reading
+const EventFieldRefresh = "Refresh"
+
+// Values of the Refresh field of the readings of a ConditionRefresh
+const (
+	RefreshStart     = "Start"
+	RefreshCondition = "Condition"
+	RefreshEnd       = "End"
+)
+
+// eventFieldType is the field identifying RefreshStart and RefreshEnd events, always selected
+const eventFieldType = "EventType"
+
+// eventItem is a monitored item receiving the events of a notifier
+type eventItem struct {
+	fields          []string // fields sent in the readings
+	typeIndex       int      // select clause of the event type, appended when not in fields
+	monitoredItemID uint32
+	refreshing      bool // between the RefreshStart and RefreshEnd events of a ConditionRefresh
+}
+
+// defaultEventFields are selected from the events of a resource without eventFields attribute
+var defaultEventFields = []string{
+	"EventId", "EventType", "SourceName", "Time", "Severity", "Message", EventFieldConditionID, "ActiveState", "AckedState",
+}
+
+// eventItemRequest returns the request monitoring the events of the notifier node, selecting the
+// fields set by the attributes of the resource, or the default fields
+func eventItemRequest(notifier *ua.NodeID, handle uint32, attrs map[string]any) (*ua.MonitoredItemCreateRequest, *eventItem, error) {
+	fields, err := eventFields(attrs)
+	if err != nil {
+		return nil, nil, err
+	}
+	item := &eventItem{fields: fields, typeIndex: slices.Index(fields, eventFieldType)}
+	clauses := fields
+	if item.typeIndex < 0 {
+		item.typeIndex = len(fields)
+		clauses = append(slices.Clone(fields), eventFieldType)
+	}
+
+	req, err := monitoredItemRequest(notifier, handle, attrs)
+	if err != nil {
+		return nil, nil, err
+	}
+	if req.RequestedParameters.Filter != nil {
+		return nil, nil, fmt.Errorf("%s is not supported for events", DEADBANDTYPE)
+	}
+
+	req.ItemToMonitor.AttributeID = ua.AttributeIDEventNotifier
+	req.RequestedParameters.Filter = ua.NewExtensionObject(eventFilter(clauses))
+	return req, item, nil
+}
+
+// eventFields returns the fields of the eventFields attribute, a list or a comma separated
+// string of browse paths relative to the event type such as Severity or ActiveState/Id
+func eventFields(attrs map[string]any) ([]string, error) {
+	v, ok := attrs[EVENTFIELDS]
+	if !ok {
+		return defaultEventFields, nil
+	}
+
+	var fields []string
+	if list, ok := v.(string); ok {
+		fields = strings.Split(list, ",")
+	} else {
+		var err error
+		if fields, err = cast.ToStringSliceE(v); err != nil {
+			return nil, fmt.Errorf("invalid %s %v", EVENTFIELDS, v)
+		}
+	}
+
+	for i, field := range fields {
+		fields[i] = strings.TrimSpace(field)
+		if fields[i] == "" || strings.HasPrefix(fields[i], "/") || strings.HasSuffix(fields[i], "/") {
+			return nil, fmt.Errorf("invalid %s %v", EVENTFIELDS, v)
+		}
+	}
+	if len(fields) == 0 {
+		return nil, fmt.Errorf("invalid %s %v", EVENTFIELDS, v)
+	}
+	return fields, nil
+}
+
+// eventFilter selects the fields of the events, in order
+func eventFilter(fields []string) *ua.EventFilter {
+	filter := &ua.EventFilter{SelectClauses: make([]*ua.SimpleAttributeOperand, len(fields))}
+	for i, field := range fields {
+		if field == EventFieldConditionID {
+			// the condition is the node of the event, not one of its properties
+			filter.SelectClauses[i] = &ua.SimpleAttributeOperand{
+				TypeDefinitionID: ua.NewNumericNodeID(0, id.ConditionType),
+				AttributeID:      ua.AttributeIDNodeID,
+			}
+			continue
+		}
+
+		var path []*ua.QualifiedName
+		for _, name := range strings.Split(field, "/") {
+			path = append(path, &ua.QualifiedName{NamespaceIndex: 0, Name: name})
+		}
+		filter.SelectClauses[i] = &ua.SimpleAttributeOperand{
+			TypeDefinitionID: ua.NewNumericNodeID(0, id.BaseEventType),
+			BrowsePath:       path,
+			AttributeID:      ua.AttributeIDValue,
+		}
+	}
+	return filter
+}
+
+// logEventFilterResult warns about the selected fields the server rejected
+func (s *Server) logEventFilterResult(resource string, fields []string, res *ua.MonitoredItemCreateResult) {
+	if res.FilterResult == nil {
+		return
+	}
+	result, ok := res.FilterResult.Value.(*ua.EventFilterResult)
+	if !ok {
+		return
+	}
+	for i, status := range result.SelectClauseResults {
+		if status != ua.StatusOK && i < len(fields) {
+			s.sdk.LoggingClient().Warnf("[%s] event field %s of %s rejected: %v", s.deviceName, fields[i], resource, status)
+		}
+	}
+}
+
+// handleEvents sends the events as Object readings of their resource, mapping each selected field to its value
+func (s *Server) handleEvents(events []*ua.EventFieldList) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, event := range events {
+		resourceName, ok := s.resourceMap[event.ClientHandle]
+		item, isEvent := s.events[event.ClientHandle]
+		if !ok || !isEvent {
+			continue
+		}
+		reading := eventReading(item.fields, event.EventFields)
+		if refresh := item.refresh(event.EventFields); refresh != "" {
+			reading[EventFieldRefresh] = refresh
+		}
+		if err := s.onIncomingDataReceived(reading, resourceName, nil); err != nil {
+			s.sdk.LoggingClient().Errorf("%v", err)
+		}
+	}
+}
+
+// refresh tracks the ConditionRefresh of the item, and returns the Refresh field of the event,
+// empty for the events which are not part of a refresh
+func (item *eventItem) refresh(values []*ua.Variant) string {
+	var eventType *ua.NodeID
+	if item.typeIndex < len(values) && values[item.typeIndex] != nil {
+		eventType, _ = values[item.typeIndex].Value().(*ua.NodeID)
+	}
+
+	switch {
+	case eventType != nil && eventType.Namespace() == 0 && eventType.IntID() == id.RefreshStartEventType:
+		item.refreshing = true
+		return RefreshStart
+	case eventType != nil && eventType.Namespace() == 0 && eventType.IntID() == id.RefreshEndEventType:
+		item.refreshing = false
+		return RefreshEnd
+	case item.refreshing:
+		return RefreshCondition
+	}
+	return ""
+}
+
+// refreshConditions calls ConditionRefresh2 for the event items of the subscription, so that the
+// server sends the state of the retained conditions, which changed while the subscription was lost.
+// Servers without ConditionRefresh2 refresh all the event items of the subscription with ConditionRefresh.
+func (s *Server) refreshConditions(sub *sharedSubscription) {
+	s.mu.Lock()
+	client := s.client
+	var items []uint32
+	for _, item := range s.events {
+		if item.monitoredItemID != 0 {
+			items = append(items, item.monitoredItemID)
+		}
+	}
+	s.mu.Unlock()
+
+	if len(items) == 0 || client == nil || sub.id == 0 {
+		return
+	}
+	subscriptionID := sub.id
+	ctx, cancel := context.WithTimeout(client.ctx, s.requestTimeout())
+	defer cancel()
+
+	slices.Sort(items)
+	for _, item := range items {
+		status, err := callConditionRefresh(ctx, client, id.ConditionType_ConditionRefresh2, subscriptionID, item)
+		switch {
+		case err != nil:
+			s.sdk.LoggingClient().Warnf("[%s] condition refresh of monitored item %d failed: %v", s.deviceName, item, err)
+		case status == ua.StatusBadMethodInvalid || status == ua.StatusBadNotImplemented || status == ua.StatusBadNotSupported:
+			status, err = callConditionRefresh(ctx, client, id.ConditionType_ConditionRefresh, subscriptionID)
+			if err == nil && status != ua.StatusOK {
+				err = status
+			}
+			if err != nil {
+				s.sdk.LoggingClient().Warnf("[%s] condition refresh of subscription %d failed: %v", s.deviceName, subscriptionID, err)
+				return
+			}
+			s.sdk.LoggingClient().Infof("[%s] conditions of subscription %d refreshed", s.deviceName, subscriptionID)
+			return
+		case status != ua.StatusOK:
+			s.sdk.LoggingClient().Warnf("[%s] condition refresh of monitored item %d failed: %v", s.deviceName, item, status)
+		default:
+			s.sdk.LoggingClient().Debugf("[%s] conditions of monitored item %d refreshed", s.deviceName, item)
+		}
+	}
+}
+
+// callConditionRefresh calls the refresh method of the ConditionType with the ids of the subscription and monitored item
+func callConditionRefresh(ctx context.Context, client *Client, method uint32, ids ...uint32) (ua.StatusCode, error) {
+	inputs := make([]*ua.Variant, len(ids))
+	for i, v := range ids {
+		inputs[i] = ua.MustVariant(v)
+	}
+	res, err := client.Call(ctx, &ua.CallMethodRequest{
+		ObjectID:       ua.NewNumericNodeID(0, id.ConditionType),
+		MethodID:       ua.NewNumericNodeID(0, method),
+		InputArguments: inputs,
+	})
+	if err != nil {
+		return 0, err
+	}
+	return res.StatusCode, nil
+}
+
+// eventReading maps the fields of an event to their values, converted to types which encode to JSON.
+// The EventId is base64 encoded, node ids are formatted as strings and localized texts keep their text.
+func eventReading(fields []string, values []*ua.Variant) map[string]any {
+	reading := make(map[string]any, len(fields))
+	for i, field := range fields {
+		if i >= len(values) || values[i] == nil {
+			reading[field] = nil
+			continue
+		}
+
+		if v, ok := values[i].Value().([]byte); ok {
+			reading[field] = base64.StdEncoding.EncodeToString(v)
+			continue
+		}
+		reading[field] = readingValue(values[i].Value())
+	}
+	return reading
+}
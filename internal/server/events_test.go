@@ -0,0 +1,233 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2025 Schneider Electric
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package server
+
+import (
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/edgexfoundry/device-opcua-go/internal/test"
+	gopcuaMocks "github.com/edgexfoundry/device-opcua-go/pkg/gopcua/mocks"
+	"github.com/edgexfoundry/device-sdk-go/v4/pkg/interfaces/mocks"
+	sdkModels "github.com/edgexfoundry/device-sdk-go/v4/pkg/models"
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/clients/logger"
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/common"
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/models"
+	"github.com/gopcua/opcua/id"
+	"github.com/gopcua/opcua/ua"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEventItemRequest(t *testing.T) {
+	tests := []struct {
+		name       string
+		attrs      map[string]any
+		wantFields []string
+		wantErr    bool
+	}{
+		{
+			name:       "OK - default fields",
+			attrs:      map[string]any{EVENTNOTIFIER: "i=2253"},
+			wantFields: defaultEventFields,
+		},
+		{
+			name:       "OK - comma separated fields",
+			attrs:      map[string]any{EVENTNOTIFIER: "i=2253", EVENTFIELDS: "Severity, ActiveState/Id"},
+			wantFields: []string{"Severity", "ActiveState/Id"},
+		},
+		{
+			name:       "OK - list of fields",
+			attrs:      map[string]any{EVENTNOTIFIER: "i=2253", EVENTFIELDS: []any{"Message", EventFieldConditionID}},
+			wantFields: []string{"Message", EventFieldConditionID},
+		},
+		{
+			name:    "NOK - empty field",
+			attrs:   map[string]any{EVENTNOTIFIER: "i=2253", EVENTFIELDS: "Severity,,Message"},
+			wantErr: true,
+		},
+		{
+			name:    "NOK - invalid browse path",
+			attrs:   map[string]any{EVENTNOTIFIER: "i=2253", EVENTFIELDS: "ActiveState/"},
+			wantErr: true,
+		},
+		{
+			name:    "NOK - deadband on events",
+			attrs:   map[string]any{EVENTNOTIFIER: "i=2253", DEADBANDTYPE: DeadbandAbsolute, DEADBANDVALUE: 1},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, item, err := eventItemRequest(ua.NewNumericNodeID(0, id.Server), 1, tt.attrs)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantFields, item.fields)
+			assert.Equal(t, ua.AttributeIDEventNotifier, req.ItemToMonitor.AttributeID)
+
+			// the event type is selected to recognize the events of a condition refresh
+			clauses := item.fields
+			if !slices.Contains(clauses, eventFieldType) {
+				clauses = append(slices.Clone(clauses), eventFieldType)
+			}
+			assert.Equal(t, eventFieldType, clauses[item.typeIndex])
+
+			filter, ok := req.RequestedParameters.Filter.Value.(*ua.EventFilter)
+			require.True(t, ok)
+			require.Len(t, filter.SelectClauses, len(clauses))
+			for i, field := range clauses {
+				clause := filter.SelectClauses[i]
+				if field == EventFieldConditionID {
+					assert.Equal(t, ua.AttributeIDNodeID, clause.AttributeID)
+					assert.Empty(t, clause.BrowsePath)
+					continue
+				}
+				assert.Equal(t, ua.AttributeIDValue, clause.AttributeID)
+				path := make([]string, len(clause.BrowsePath))
+				for j, name := range clause.BrowsePath {
+					path[j] = name.Name
+				}
+				assert.Equal(t, field, strings.Join(path, "/"))
+			}
+		})
+	}
+}
+
+func TestEventReading(t *testing.T) {
+	eventTime := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	fields := []string{"EventId", "EventType", "SourceName", "Time", "Severity", "Message", "ActiveState/Id", "Missing"}
+	values := []*ua.Variant{
+		ua.MustVariant([]byte{0x01, 0x02}),
+		ua.MustVariant(ua.NewNumericNodeID(0, id.OffNormalAlarmType)),
+		ua.MustVariant("Boiler"),
+		ua.MustVariant(eventTime),
+		ua.MustVariant(uint16(700)),
+		ua.MustVariant(&ua.LocalizedText{Text: "Temperature high"}),
+		ua.MustVariant(true),
+	}
+
+	assert.Equal(t, map[string]any{
+		"EventId":        "AQI=",
+		"EventType":      "i=10637",
+		"SourceName":     "Boiler",
+		"Time":           eventTime,
+		"Severity":       uint16(700),
+		"Message":        "Temperature high",
+		"ActiveState/Id": true,
+		"Missing":        nil,
+	}, eventReading(fields, values))
+}
+
+func TestSharedSubscription_deliverEvents(t *testing.T) {
+	dsMock := mocks.NewDeviceServiceSDK(t)
+	dsMock.On("LoggingClient").Return(logger.NewMockClient()).Maybe()
+	values := make(chan *sdkModels.AsyncValues, 1)
+	dsMock.On("AsyncValuesChannel").Return(values)
+	dsMock.On("DeviceResource", "Test", "Alarms").
+		Return(models.DeviceResource{Name: "Alarms", Properties: models.ResourceProperties{ValueType: common.ValueTypeObject}}, true)
+
+	s := NewServer("Test", dsMock, nil)
+	shared := &sharedSubscription{handles: make(map[uint32]*Server)}
+	handle := shared.register(s)
+	s.resourceMap[handle] = "Alarms"
+	s.events = map[uint32]*eventItem{handle: {fields: []string{"Severity"}, typeIndex: 1}}
+
+	delivered := shared.deliver(&ua.EventNotificationList{Events: []*ua.EventFieldList{
+		{ClientHandle: handle, EventFields: []*ua.Variant{ua.MustVariant(uint16(500))}},
+		{ClientHandle: 999},
+	}})
+	assert.Equal(t, 1, delivered)
+
+	reading := <-values
+	require.Len(t, reading.CommandValues, 1)
+	assert.Equal(t, common.ValueTypeObject, reading.CommandValues[0].Type)
+	assert.Equal(t, map[string]any{"Severity": uint16(500)}, reading.CommandValues[0].Value)
+}
+
+func TestEventItem_refresh(t *testing.T) {
+	eventType := func(t uint32) []*ua.Variant {
+		return []*ua.Variant{ua.MustVariant(uint16(100)), ua.MustVariant(ua.NewNumericNodeID(0, t))}
+	}
+	item := &eventItem{fields: []string{"Severity"}, typeIndex: 1}
+
+	assert.Empty(t, item.refresh(eventType(id.OffNormalAlarmType)))
+	assert.Equal(t, RefreshStart, item.refresh(eventType(id.RefreshStartEventType)))
+	assert.Equal(t, RefreshCondition, item.refresh(eventType(id.OffNormalAlarmType)))
+	assert.Equal(t, RefreshCondition, item.refresh([]*ua.Variant{ua.MustVariant(uint16(100))}))
+	assert.Equal(t, RefreshEnd, item.refresh(eventType(id.RefreshEndEventType)))
+	assert.Empty(t, item.refresh(eventType(id.OffNormalAlarmType)))
+}
+
+func TestServer_refreshConditions(t *testing.T) {
+	refresh := func(method uint32) any {
+		return mock.MatchedBy(func(r *ua.CallMethodRequest) bool {
+			return r.ObjectID.IntID() == id.ConditionType && r.MethodID.IntID() == method
+		})
+	}
+	tests := []struct {
+		name   string
+		events map[uint32]*eventItem
+		setup  func(client *gopcuaMocks.MockClient)
+	}{
+		{
+			name:   "OK - no event items",
+			events: map[uint32]*eventItem{},
+		},
+		{
+			name:   "OK - refresh per monitored item",
+			events: map[uint32]*eventItem{1: {monitoredItemID: 10}, 2: {monitoredItemID: 20}, 3: {}},
+			setup: func(client *gopcuaMocks.MockClient) {
+				client.On("Call", mock.Anything, mock.MatchedBy(func(r *ua.CallMethodRequest) bool {
+					return r.MethodID.IntID() == id.ConditionType_ConditionRefresh2 &&
+						r.InputArguments[0].Value() == uint32(7) && r.InputArguments[1].Value() == uint32(10)
+				})).Return(&ua.CallMethodResult{StatusCode: ua.StatusOK}, nil).Once()
+				client.On("Call", mock.Anything, mock.MatchedBy(func(r *ua.CallMethodRequest) bool {
+					return r.MethodID.IntID() == id.ConditionType_ConditionRefresh2 && r.InputArguments[1].Value() == uint32(20)
+				})).Return(&ua.CallMethodResult{StatusCode: ua.StatusOK}, nil).Once()
+			},
+		},
+		{
+			name:   "OK - fall back to the refresh of the subscription",
+			events: map[uint32]*eventItem{1: {monitoredItemID: 10}, 2: {monitoredItemID: 20}},
+			setup: func(client *gopcuaMocks.MockClient) {
+				client.On("Call", mock.Anything, refresh(id.ConditionType_ConditionRefresh2)).
+					Return(&ua.CallMethodResult{StatusCode: ua.StatusBadMethodInvalid}, nil).Once()
+				client.On("Call", mock.Anything, mock.MatchedBy(func(r *ua.CallMethodRequest) bool {
+					return r.MethodID.IntID() == id.ConditionType_ConditionRefresh &&
+						len(r.InputArguments) == 1 && r.InputArguments[0].Value() == uint32(7)
+				})).Return(&ua.CallMethodResult{StatusCode: ua.StatusOK}, nil).Once()
+			},
+		},
+		{
+			name:   "NOK - refresh failed",
+			events: map[uint32]*eventItem{1: {monitoredItemID: 10}},
+			setup: func(client *gopcuaMocks.MockClient) {
+				client.On("Call", mock.Anything, refresh(id.ConditionType_ConditionRefresh2)).
+					Return(nil, ua.StatusBadTimeout).Once()
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewServer("Test", test.NewDSMock(t), nil)
+			clientMock := gopcuaMocks.NewMockClient(t)
+			s.client = &Client{clientMock, s.context.ctx}
+			s.events = tt.events
+			if tt.setup != nil {
+				tt.setup(clientMock)
+			}
+
+			s.refreshConditions(&sharedSubscription{id: 7})
+		})
+	}
+}
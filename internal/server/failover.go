@@ -0,0 +1,179 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2025 Schneider Electric
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/gopcua/opcua"
+	"github.com/gopcua/opcua/id"
+	"github.com/gopcua/opcua/ua"
+)
+
+// failoverCheckInterval is how often the health of the active endpoint is checked
+// by the subscription listener, when the device has redundant endpoints
+const failoverCheckInterval = 5 * time.Second
+
+// connectEndpoint connects to the first endpoint accepting the connection, in the configured order
+// starting from the active endpoint. When failing over, the active endpoint is tried last.
+func (s *Server) connectEndpoint(failover bool) error {
+	endpoints := s.config.EndpointURLs()
+	if len(endpoints) == 0 {
+		return fmt.Errorf("[%s] no endpoint configured", s.deviceName)
+	}
+
+	s.mu.Lock()
+	previous := s.active
+	s.mu.Unlock()
+
+	start := max(slices.Index(endpoints, previous), 0)
+	if failover && previous != "" {
+		start++
+	}
+
+	var errs []error
+	for i := range endpoints {
+		endpoint := endpoints[(start+i)%len(endpoints)]
+		if err := s.connectTo(endpoint); err != nil {
+			if len(endpoints) > 1 {
+				s.sdk.LoggingClient().Warnf("[%s] endpoint %s unavailable: %v", s.deviceName, endpoint, err)
+			}
+			errs = append(errs, err)
+			continue
+		}
+
+		s.mu.Lock()
+		s.active = endpoint
+		s.mu.Unlock()
+		s.readOperationLimits()
+
+		if previous != "" && previous != endpoint {
+			s.sdk.LoggingClient().Infof("[%s] failed over from endpoint %s to %s", s.deviceName, previous, endpoint)
+		} else {
+			s.sdk.LoggingClient().Infof("[%s] connected to endpoint %s", s.deviceName, endpoint)
+		}
+		return nil
+	}
+
+	if len(errs) == 1 {
+		return errs[0]
+	}
+	return fmt.Errorf("[%s] no endpoint available: %w", s.deviceName, errors.Join(errs...))
+}
+
+// connectTo creates a client for the endpoint and connects it, making sure
+// the server is able to serve data when a minimum service level is configured
+func (s *Server) connectTo(endpoint string) error {
+	// with reverse connect, the client dials a local bridge to the connections opened by the server
+	dialURL := endpoint
+	if s.config.ReverseConnect {
+		var err error
+		if dialURL, err = s.reverseEndpoint(endpoint); err != nil {
+			return err
+		}
+	}
+
+	// the session is keyed by the configured endpoint, as every device has its own bridge. The
+	// client library sends the URL it dials in CreateSession, the Hello carries the endpoint.
+	conn, err := s.initClient(endpoint, dialURL)
+	if err != nil {
+		return err
+	}
+
+	session, owner, err := sessions.acquire(s, conn)
+	if err != nil {
+		return err
+	}
+
+	// the previous session is left first, unless the server connected to it again
+	s.mu.Lock()
+	release := func() {}
+	if s.session != session {
+		release = s.releaseSession()
+	}
+	s.session = session
+	s.client = &Client{
+		session.client,
+		context.Background(),
+	}
+	client := s.client
+	s.mu.Unlock()
+	release()
+
+	if owner && s.config.AuthMode == AuthModeIssued {
+		go s.refreshIssuedToken(s.context.ctx, client)
+	}
+
+	if s.config.MinServiceLevel == 0 {
+		return nil
+	}
+
+	level, err := s.serviceLevel(client)
+	if err == nil && level < byte(s.config.MinServiceLevel) {
+		err = fmt.Errorf("service level %d is below the minimum %d", level, s.config.MinServiceLevel)
+	}
+	if err != nil {
+		s.closeClient()
+		return fmt.Errorf("[%s] endpoint %s rejected: %v", s.deviceName, endpoint, err)
+	}
+	return nil
+}
+
+// serviceLevel reads the ServiceLevel of the server, which tells how able it is to serve
+// data in a redundant set: 0 in maintenance, 1 no data, up to 255 healthy
+func (s *Server) serviceLevel(client *Client) (byte, error) {
+	ctx, cancel := s.requestContext(client)
+	defer cancel()
+	resp, err := client.Read(ctx, &ua.ReadRequest{
+		NodesToRead: []*ua.ReadValueID{{
+			NodeID:      ua.NewNumericNodeID(0, id.Server_ServiceLevel),
+			AttributeID: ua.AttributeIDValue,
+		}},
+		TimestampsToReturn: ua.TimestampsToReturnNeither,
+	})
+	if err != nil {
+		return 0, err
+	}
+	if len(resp.Results) == 0 || resp.Results[0].Status != ua.StatusOK || resp.Results[0].Value == nil {
+		return 0, fmt.Errorf("unable to read the service level")
+	}
+
+	level, ok := resp.Results[0].Value.Value().(byte)
+	if !ok {
+		return 0, fmt.Errorf("unexpected service level type %T", resp.Results[0].Value.Value())
+	}
+	return level, nil
+}
+
+// endpointHealthy reports whether the active endpoint is still connected with the client and able to serve data
+func (s *Server) endpointHealthy(client *Client) bool {
+	if client.State() != opcua.Connected {
+		return false
+	}
+	if s.config.MinServiceLevel == 0 {
+		return true
+	}
+
+	level, err := s.serviceLevel(client)
+	if err != nil {
+		s.sdk.LoggingClient().Warnf("[%s] failed to read service level of endpoint %s: %v", s.deviceName, s.active, err)
+		return false
+	}
+	return level >= byte(s.config.MinServiceLevel)
+}
+
+// closeClient leaves the session of the active endpoint, keeping the server context
+func (s *Server) closeClient() {
+	s.mu.Lock()
+	release := s.releaseSession()
+	s.mu.Unlock()
+	release()
+}
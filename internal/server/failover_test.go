@@ -0,0 +1,186 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2025 Schneider Electric
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package server
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/edgexfoundry/device-opcua-go/internal/test"
+	"github.com/edgexfoundry/device-opcua-go/pkg/gopcua"
+	gopcuaMocks "github.com/edgexfoundry/device-opcua-go/pkg/gopcua/mocks"
+	"github.com/gopcua/opcua"
+	"github.com/gopcua/opcua/ua"
+	"github.com/stretchr/testify/mock"
+)
+
+const (
+	primaryEndpoint   = "opc.tcp://primary"
+	secondaryEndpoint = "opc.tcp://secondary"
+)
+
+// mockEndpoints makes every endpoint URL return an unsecured endpoint, and uses
+// the mocked client configured for it
+func mockEndpoints(t *testing.T, clients map[string]*gopcuaMocks.MockClient) {
+	origGetEndpoints, origNewClient := gopcua.GetEndpoints, gopcua.NewClient
+	t.Cleanup(func() { gopcua.GetEndpoints, gopcua.NewClient = origGetEndpoints, origNewClient })
+	newSessionPool(t)
+
+	gopcua.GetEndpoints = func(ctx context.Context, endpointURL string, opts ...opcua.Option) ([]*ua.EndpointDescription, error) {
+		if _, ok := clients[endpointURL]; !ok {
+			return nil, fmt.Errorf("bad endpoint")
+		}
+		return []*ua.EndpointDescription{newEndpoint(ua.SecurityPolicyURINone, ua.MessageSecurityModeNone, 0)}, nil
+	}
+	gopcua.NewClient = func(endpoint string, opts ...opcua.Option) (gopcua.Client, error) {
+		return clients[endpoint], nil
+	}
+}
+
+func serviceLevelResponse(level byte) *ua.ReadResponse {
+	return &ua.ReadResponse{Results: []*ua.DataValue{{Status: ua.StatusOK, Value: ua.MustVariant(level)}}}
+}
+
+func TestServer_connectEndpoint(t *testing.T) {
+	tests := []struct {
+		name       string
+		config     *Config
+		active     string
+		failover   bool
+		setup      func(primary, secondary *gopcuaMocks.MockClient)
+		wantActive string
+		wantErr    bool
+	}{
+		{
+			name:   "OK - primary endpoint",
+			config: &Config{Endpoint: primaryEndpoint, Endpoints: []string{secondaryEndpoint}, Policy: "None", Mode: "None"},
+			setup: func(primary, secondary *gopcuaMocks.MockClient) {
+				primary.On("Connect", mock.Anything).Return(nil)
+				primary.On("Read", mock.Anything, mock.Anything).Return(maxNodesPerReadResponse(0), nil)
+			},
+			wantActive: primaryEndpoint,
+		},
+		{
+			name:   "OK - primary endpoint unavailable",
+			config: &Config{Endpoint: primaryEndpoint, Endpoints: []string{secondaryEndpoint}, Policy: "None", Mode: "None"},
+			setup: func(primary, secondary *gopcuaMocks.MockClient) {
+				primary.On("Connect", mock.Anything).Return(fmt.Errorf("error"))
+				secondary.On("Connect", mock.Anything).Return(nil)
+				secondary.On("Read", mock.Anything, mock.Anything).Return(maxNodesPerReadResponse(0), nil)
+			},
+			wantActive: secondaryEndpoint,
+		},
+		{
+			name:     "OK - failover to the next endpoint",
+			config:   &Config{Endpoints: []string{primaryEndpoint, secondaryEndpoint}, Policy: "None", Mode: "None"},
+			active:   secondaryEndpoint,
+			failover: true,
+			setup: func(primary, secondary *gopcuaMocks.MockClient) {
+				primary.On("Connect", mock.Anything).Return(nil)
+				primary.On("Read", mock.Anything, mock.Anything).Return(maxNodesPerReadResponse(0), nil)
+			},
+			wantActive: primaryEndpoint,
+		},
+		{
+			name:   "OK - primary endpoint below minimum service level",
+			config: &Config{Endpoint: primaryEndpoint, Endpoints: []string{secondaryEndpoint}, Policy: "None", Mode: "None", MinServiceLevel: 200},
+			setup: func(primary, secondary *gopcuaMocks.MockClient) {
+				primary.On("Connect", mock.Anything).Return(nil)
+				primary.On("Read", mock.Anything, mock.Anything).Return(serviceLevelResponse(1), nil)
+				primary.On("State").Return(opcua.Connected)
+				primary.On("Close", mock.Anything).Return(nil)
+				secondary.On("Connect", mock.Anything).Return(nil)
+				secondary.On("Read", mock.Anything, mock.Anything).Return(serviceLevelResponse(255), nil)
+			},
+			wantActive: secondaryEndpoint,
+		},
+		{
+			name:   "NOK - no endpoint available",
+			config: &Config{Endpoint: primaryEndpoint, Endpoints: []string{secondaryEndpoint}, Policy: "None", Mode: "None"},
+			setup: func(primary, secondary *gopcuaMocks.MockClient) {
+				primary.On("Connect", mock.Anything).Return(fmt.Errorf("error"))
+				secondary.On("Connect", mock.Anything).Return(fmt.Errorf("error"))
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			primary, secondary := gopcuaMocks.NewMockClient(t), gopcuaMocks.NewMockClient(t)
+			mockEndpoints(t, map[string]*gopcuaMocks.MockClient{primaryEndpoint: primary, secondaryEndpoint: secondary})
+			tt.setup(primary, secondary)
+
+			s := NewServer("Test", test.NewDSMock(t), nil)
+			s.config = tt.config
+			s.active = tt.active
+
+			err := s.connectEndpoint(tt.failover)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("connectEndpoint() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && s.active != tt.wantActive {
+				t.Errorf("active endpoint = %s, want %s", s.active, tt.wantActive)
+			}
+		})
+	}
+}
+
+func TestServer_endpointHealthy(t *testing.T) {
+	tests := []struct {
+		name   string
+		config *Config
+		setup  func(client *gopcuaMocks.MockClient)
+		want   bool
+	}{
+		{
+			name:   "OK - connected",
+			config: &Config{},
+			setup: func(client *gopcuaMocks.MockClient) {
+				client.On("State").Return(opcua.Connected)
+			},
+			want: true,
+		},
+		{
+			name:   "NOK - reconnecting",
+			config: &Config{},
+			setup: func(client *gopcuaMocks.MockClient) {
+				client.On("State").Return(opcua.Reconnecting)
+			},
+		},
+		{
+			name:   "NOK - service level too low",
+			config: &Config{MinServiceLevel: 200},
+			setup: func(client *gopcuaMocks.MockClient) {
+				client.On("State").Return(opcua.Connected)
+				client.On("Read", mock.Anything, mock.Anything).Return(serviceLevelResponse(100), nil)
+			},
+		},
+		{
+			name:   "NOK - service level unreadable",
+			config: &Config{MinServiceLevel: 200},
+			setup: func(client *gopcuaMocks.MockClient) {
+				client.On("State").Return(opcua.Connected)
+				client.On("Read", mock.Anything, mock.Anything).Return(nil, fmt.Errorf("error"))
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clientMock := gopcuaMocks.NewMockClient(t)
+			tt.setup(clientMock)
+
+			s := NewServer("Test", test.NewDSMock(t), nil)
+			s.config = tt.config
+			s.client = &Client{clientMock, s.context.ctx}
+
+			if got := s.endpointHealthy(s.client); got != tt.want {
+				t.Errorf("endpointHealthy() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
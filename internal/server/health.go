@@ -0,0 +1,132 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2025 Schneider Electric
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package server
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/models"
+	"github.com/gopcua/opcua"
+	"github.com/gopcua/opcua/id"
+	"github.com/gopcua/opcua/ua"
+)
+
+// monitorHealth periodically checks the state of the server, setting the operating state
+// of the device down after repeated failures and up again once the server recovers
+func (s *Server) monitorHealth(ctx context.Context) {
+	failures := 0
+	for {
+		s.mu.Lock()
+		interval := defaultHealthCheckInterval
+		if s.config != nil {
+			interval = s.config.HealthCheckIntervalDuration()
+		}
+		s.mu.Unlock()
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(interval):
+		}
+
+		failures = s.checkHealth(failures)
+	}
+}
+
+// checkHealth runs a single health check given the number of consecutive failures so far,
+// and returns the updated count
+func (s *Server) checkHealth(failures int) int {
+	if err := s.serverRunning(); err != nil {
+		failures++
+		s.sdk.LoggingClient().Debugf("[%s] health check %d failed: %v", s.deviceName, failures, err)
+
+		threshold := defaultHealthCheckFailures
+		s.mu.Lock()
+		if s.config != nil {
+			threshold = s.config.HealthCheckFailureThreshold()
+		}
+		s.mu.Unlock()
+
+		if failures >= threshold {
+			s.setOperatingState(models.Down, err)
+		}
+		return failures
+	}
+
+	s.setOperatingState(models.Up, nil)
+	return 0
+}
+
+// serverRunning reads the state of the server on the current connection, which must be running.
+// Reconnecting is left to the subscription supervisor, the server is reported down meanwhile.
+func (s *Server) serverRunning() error {
+	s.mu.Lock()
+	client := s.client
+	timeout := s.requestTimeout()
+	s.mu.Unlock()
+
+	if client == nil {
+		return fmt.Errorf("not connected")
+	}
+	if state := client.State(); state == opcua.Closed || state == opcua.Disconnected {
+		return fmt.Errorf("connection %v", state)
+	}
+
+	ctx, cancel := context.WithTimeout(client.ctx, timeout)
+	defer cancel()
+
+	resp, err := client.Read(ctx, &ua.ReadRequest{
+		NodesToRead: []*ua.ReadValueID{{
+			NodeID:      ua.NewNumericNodeID(0, id.Server_ServerStatus_State),
+			AttributeID: ua.AttributeIDValue,
+		}},
+		TimestampsToReturn: ua.TimestampsToReturnNeither,
+	})
+	if err != nil {
+		return err
+	}
+	if len(resp.Results) == 0 || resp.Results[0].Status != ua.StatusOK || resp.Results[0].Value == nil {
+		return fmt.Errorf("unable to read the server state")
+	}
+
+	state, ok := resp.Results[0].Value.Value().(int32)
+	if !ok {
+		return fmt.Errorf("unexpected server state type %T", resp.Results[0].Value.Value())
+	}
+	if ua.ServerState(state) != ua.ServerStateRunning {
+		return fmt.Errorf("server state is %v", ua.ServerState(state))
+	}
+	return nil
+}
+
+// setOperatingState reports the operating state of the device through the SDK when it changes
+func (s *Server) setOperatingState(state models.OperatingState, cause error) {
+	s.mu.Lock()
+	if s.health == state {
+		s.mu.Unlock()
+		return
+	}
+	s.health = state
+	s.mu.Unlock()
+
+	if err := s.sdk.UpdateDeviceOperatingState(s.deviceName, state); err != nil {
+		s.sdk.LoggingClient().Errorf("[%s] failed to set operating state %s: %v", s.deviceName, state, err)
+		// report the state again with the next check
+		s.mu.Lock()
+		s.health = ""
+		s.mu.Unlock()
+		return
+	}
+
+	if cause != nil {
+		s.sdk.LoggingClient().Warnf("[%s] device is %s: %v", s.deviceName, state, cause)
+	} else {
+		s.sdk.LoggingClient().Infof("[%s] device is %s", s.deviceName, state)
+	}
+}
@@ -0,0 +1,154 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2025 Schneider Electric
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package server
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/edgexfoundry/device-opcua-go/internal/test"
+	gopcuaMocks "github.com/edgexfoundry/device-opcua-go/pkg/gopcua/mocks"
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/models"
+	"github.com/gopcua/opcua"
+	"github.com/gopcua/opcua/ua"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func serverStateResponse(state ua.ServerState) *ua.ReadResponse {
+	return &ua.ReadResponse{Results: []*ua.DataValue{{Status: ua.StatusOK, Value: ua.MustVariant(int32(state))}}}
+}
+
+func TestServer_checkHealth(t *testing.T) {
+	tests := []struct {
+		name         string
+		config       *Config
+		health       models.OperatingState
+		failures     int
+		noClient     bool
+		connState    opcua.ConnState
+		response     *ua.ReadResponse
+		readErr      error
+		wantState    models.OperatingState
+		wantFailures int
+	}{
+		{
+			name:      "OK - running server reported up",
+			config:    &Config{},
+			response:  serverStateResponse(ua.ServerStateRunning),
+			wantState: models.Up,
+		},
+		{
+			name:     "OK - running server already up",
+			config:   &Config{},
+			health:   models.Up,
+			failures: 1,
+			response: serverStateResponse(ua.ServerStateRunning),
+		},
+		{
+			name:      "OK - recovered server reported up",
+			config:    &Config{},
+			health:    models.Down,
+			failures:  5,
+			response:  serverStateResponse(ua.ServerStateRunning),
+			wantState: models.Up,
+		},
+		{
+			name:         "NOK - failure below the threshold",
+			config:       &Config{},
+			health:       models.Up,
+			readErr:      fmt.Errorf("error"),
+			wantFailures: 1,
+		},
+		{
+			name:         "NOK - failures reaching the threshold",
+			config:       &Config{},
+			health:       models.Up,
+			failures:     2,
+			readErr:      fmt.Errorf("error"),
+			wantState:    models.Down,
+			wantFailures: 3,
+		},
+		{
+			name:         "NOK - server not running",
+			config:       &Config{HealthCheckFailures: 1},
+			health:       models.Up,
+			response:     serverStateResponse(ua.ServerStateSuspended),
+			wantState:    models.Down,
+			wantFailures: 1,
+		},
+		{
+			name:         "NOK - server already down",
+			config:       &Config{HealthCheckFailures: 1},
+			health:       models.Down,
+			failures:     4,
+			response:     serverStateResponse(ua.ServerStateFailed),
+			wantFailures: 5,
+		},
+		{
+			name:         "NOK - not connected",
+			config:       &Config{HealthCheckFailures: 1},
+			health:       models.Up,
+			noClient:     true,
+			wantState:    models.Down,
+			wantFailures: 1,
+		},
+		{
+			name:         "NOK - connection lost is left to the supervisor",
+			config:       &Config{HealthCheckFailures: 1},
+			health:       models.Up,
+			connState:    opcua.Disconnected,
+			wantState:    models.Down,
+			wantFailures: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dsMock := test.NewDSMock(t)
+			clientMock := gopcuaMocks.NewMockClient(t)
+			connState := opcua.Connected
+			if tt.connState != opcua.Closed {
+				connState = tt.connState
+			}
+			clientMock.On("State").Return(connState).Maybe()
+			if connState == opcua.Connected && !tt.noClient {
+				clientMock.On("Read", test.RequestContext(), mock.Anything).Return(tt.response, tt.readErr)
+			}
+			if tt.wantState != "" {
+				dsMock.On("UpdateDeviceOperatingState", "Test", tt.wantState).Return(nil).Once()
+			}
+
+			s := NewServer("Test", dsMock, nil)
+			s.config = tt.config
+			s.health = tt.health
+			if !tt.noClient {
+				s.client = &Client{clientMock, s.context.ctx}
+			}
+
+			failures := s.checkHealth(tt.failures)
+			assert.Equal(t, tt.wantFailures, failures)
+			if tt.wantState != "" {
+				assert.Equal(t, tt.wantState, s.health)
+			}
+			clientMock.AssertNotCalled(t, "Connect", mock.Anything)
+		})
+	}
+}
+
+func TestServer_setOperatingState(t *testing.T) {
+	t.Run("NOK - update failed is retried", func(t *testing.T) {
+		dsMock := test.NewDSMock(t)
+		dsMock.On("UpdateDeviceOperatingState", "Test", models.OperatingState(models.Down)).Return(fmt.Errorf("error")).Once()
+		dsMock.On("UpdateDeviceOperatingState", "Test", models.OperatingState(models.Down)).Return(nil).Once()
+
+		s := NewServer("Test", dsMock, nil)
+		s.setOperatingState(models.Down, fmt.Errorf("error"))
+		assert.Empty(t, s.health)
+		s.setOperatingState(models.Down, fmt.Errorf("error"))
+		assert.Equal(t, models.OperatingState(models.Down), s.health)
+	})
+}
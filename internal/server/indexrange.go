@@ -0,0 +1,99 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2025 Schneider Electric
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package server
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	sdkModel "github.com/edgexfoundry/device-sdk-go/v4/pkg/models"
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/common"
+	"github.com/spf13/cast"
+)
+
+// indexRange returns the index range of the resource, given by its indexRange attribute, and
+// whether it selects a single element of each dimension. The range of a resource whose valueType
+// is not an array must select a single element, which is read and written as a scalar.
+func indexRange(req sdkModel.CommandRequest) (string, bool, error) {
+	v, ok := req.Attributes[INDEXRANGE]
+	if !ok {
+		return "", false, nil
+	}
+
+	numericRange := strings.TrimSpace(cast.ToString(v))
+	single, err := parseNumericRange(numericRange)
+	if err != nil {
+		return "", false, fmt.Errorf("invalid %s %v: %w", INDEXRANGE, v, err)
+	}
+	if !single && !isArrayType(req.Type) {
+		return "", false, fmt.Errorf("%s %s selects several elements of the %s resource", INDEXRANGE, numericRange, req.Type)
+	}
+	return numericRange, single && !isArrayType(req.Type), nil
+}
+
+// parseNumericRange checks the syntax of a NumericRange, a list of dimensions each given as an
+// index or as min:max with min lower than max, and reports whether it selects a single element
+func parseNumericRange(numericRange string) (bool, error) {
+	if numericRange == "" {
+		return false, fmt.Errorf("empty range")
+	}
+
+	single := true
+	for _, dimension := range strings.Split(numericRange, ",") {
+		bounds := strings.Split(dimension, ":")
+		if len(bounds) > 2 {
+			return false, fmt.Errorf("dimension %q has more than two bounds", dimension)
+		}
+		var indexes []uint64
+		for _, bound := range bounds {
+			index, err := strconv.ParseUint(bound, 10, 32)
+			if err != nil {
+				return false, fmt.Errorf("dimension %q is not an index or a min:max range", dimension)
+			}
+			indexes = append(indexes, index)
+		}
+		if len(indexes) == 2 {
+			if indexes[0] >= indexes[1] {
+				return false, fmt.Errorf("dimension %q does not have a min lower than its max", dimension)
+			}
+			single = false
+		}
+	}
+	return single, nil
+}
+
+// isArrayType reports whether the valueType holds several elements, the bytes of Binary values being its elements
+func isArrayType(valueType string) bool {
+	return strings.HasSuffix(valueType, "Array") || valueType == common.ValueTypeBinary
+}
+
+// scalarElement returns the element of the array read with a single element range of a scalar
+// resource, the value itself when it is not such an array
+func scalarElement(v any) any {
+	rv := reflect.ValueOf(v)
+	for rv.IsValid() && rv.Kind() == reflect.Slice && rv.Len() == 1 {
+		rv = rv.Index(0)
+	}
+	if !rv.IsValid() {
+		return v
+	}
+	return rv.Interface()
+}
+
+// elementArray returns the value of a scalar resource written with a single element range as an
+// array with one element in each dimension of the range
+func elementArray(numericRange string, v any) any {
+	rv := reflect.ValueOf(v)
+	for range strings.Split(numericRange, ",") {
+		array := reflect.MakeSlice(reflect.SliceOf(rv.Type()), 1, 1)
+		array.Index(0).Set(rv)
+		rv = array
+	}
+	return rv.Interface()
+}
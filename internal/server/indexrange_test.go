@@ -0,0 +1,141 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2025 Schneider Electric
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package server
+
+import (
+	"testing"
+
+	"github.com/edgexfoundry/device-opcua-go/internal/test"
+	gopcuaMocks "github.com/edgexfoundry/device-opcua-go/pkg/gopcua/mocks"
+	sdkModel "github.com/edgexfoundry/device-sdk-go/v4/pkg/models"
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/common"
+	"github.com/gopcua/opcua"
+	"github.com/gopcua/opcua/ua"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIndexRange(t *testing.T) {
+	tests := []struct {
+		name       string
+		valueType  string
+		indexRange any
+		want       string
+		wantSingle bool
+		wantErr    bool
+	}{
+		{name: "OK - no range", valueType: common.ValueTypeFloat64},
+		{name: "OK - element of scalar resource", valueType: common.ValueTypeFloat64, indexRange: "17", want: "17", wantSingle: true},
+		{name: "OK - numeric element", valueType: common.ValueTypeInt32, indexRange: 17, want: "17", wantSingle: true},
+		{name: "OK - element of matrix", valueType: common.ValueTypeInt32, indexRange: "1,2", want: "1,2", wantSingle: true},
+		{name: "OK - slice of array resource", valueType: common.ValueTypeFloat64Array, indexRange: "0:9", want: "0:9"},
+		{name: "OK - element of array resource", valueType: common.ValueTypeFloat64Array, indexRange: "17", want: "17"},
+		{name: "OK - bytes of binary resource", valueType: common.ValueTypeBinary, indexRange: "2:5", want: "2:5"},
+		{name: "NOK - slice of scalar resource", valueType: common.ValueTypeFloat64, indexRange: "0:9", wantErr: true},
+		{name: "NOK - empty range", valueType: common.ValueTypeFloat64Array, indexRange: "", wantErr: true},
+		{name: "NOK - min not lower than max", valueType: common.ValueTypeFloat64Array, indexRange: "5:5", wantErr: true},
+		{name: "NOK - negative index", valueType: common.ValueTypeFloat64Array, indexRange: "-1", wantErr: true},
+		{name: "NOK - three bounds", valueType: common.ValueTypeFloat64Array, indexRange: "1:2:3", wantErr: true},
+		{name: "NOK - not a number", valueType: common.ValueTypeFloat64Array, indexRange: "first", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := sdkModel.CommandRequest{Attributes: map[string]any{}, Type: tt.valueType}
+			if tt.indexRange != nil {
+				req.Attributes[INDEXRANGE] = tt.indexRange
+			}
+			got, single, err := indexRange(req)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantSingle, single)
+		})
+	}
+}
+
+func TestScalarElement(t *testing.T) {
+	assert.Equal(t, 1.5, scalarElement([]float64{1.5}))
+	assert.Equal(t, int32(7), scalarElement([][]int32{{7}}))
+	assert.Equal(t, []float64{1, 2}, scalarElement([]float64{1, 2}))
+	assert.Equal(t, "a", scalarElement("a"))
+	assert.Nil(t, scalarElement(nil))
+}
+
+func TestElementArray(t *testing.T) {
+	assert.Equal(t, []float64{1.5}, elementArray("17", 1.5))
+	assert.Equal(t, [][]int32{{7}}, elementArray("1,2", int32(7)))
+}
+
+func TestServer_indexRange(t *testing.T) {
+	node := ua.NewStringNodeID(2, "Recipe")
+
+	t.Run("OK - read element and slice", func(t *testing.T) {
+		reqs := []sdkModel.CommandRequest{{
+			DeviceResourceName: "RecipeStep17",
+			Attributes:         map[string]any{NODE: node.String(), INDEXRANGE: "17"},
+			Type:               common.ValueTypeFloat64,
+		}, {
+			DeviceResourceName: "RecipeHead",
+			Attributes:         map[string]any{NODE: node.String(), INDEXRANGE: "0:2"},
+			Type:               common.ValueTypeFloat64Array,
+		}}
+
+		s := NewServer("Test", test.NewDSMock(t), nil)
+		s.config = &Config{Endpoint: test.Address}
+		clientMock := gopcuaMocks.NewMockClient(t)
+		s.client = &Client{clientMock, s.context.ctx}
+		clientMock.On("State").Return(opcua.Connected)
+		clientMock.On("Read", test.RequestContext(), mock.MatchedBy(func(r *ua.ReadRequest) bool {
+			return len(r.NodesToRead) == 2 && r.NodesToRead[0].IndexRange == "17" && r.NodesToRead[1].IndexRange == "0:2"
+		})).Return(&ua.ReadResponse{Results: []*ua.DataValue{
+			{Value: ua.MustVariant([]float64{42.5})},
+			{Value: ua.MustVariant([]float64{1, 2, 3})},
+		}}, nil).Once()
+
+		got, err := s.ProcessReadCommands(reqs)
+		require.NoError(t, err)
+		assert.Equal(t, 42.5, got[0].Value)
+		assert.Equal(t, []float64{1, 2, 3}, got[1].Value)
+	})
+
+	t.Run("OK - write element", func(t *testing.T) {
+		reqs := []sdkModel.CommandRequest{{
+			DeviceResourceName: "RecipeStep17",
+			Attributes:         map[string]any{NODE: node.String(), INDEXRANGE: "17"},
+			Type:               common.ValueTypeFloat64,
+		}}
+		params := []*sdkModel.CommandValue{{DeviceResourceName: "RecipeStep17", Type: common.ValueTypeFloat64, Value: 42.5}}
+
+		s := NewServer("Test", test.NewDSMock(t), nil)
+		clientMock := gopcuaMocks.NewMockClient(t)
+		s.client = &Client{clientMock, s.context.ctx}
+		clientMock.On("State").Return(opcua.Connected)
+		clientMock.On("Write", test.RequestContext(), mock.MatchedBy(func(r *ua.WriteRequest) bool {
+			w := r.NodesToWrite[0]
+			v, ok := w.Value.Value.Value().([]float64)
+			return w.IndexRange == "17" && ok && len(v) == 1 && v[0] == 42.5
+		})).Return(&ua.WriteResponse{Results: []ua.StatusCode{ua.StatusOK}}, nil).Once()
+
+		require.NoError(t, s.ProcessWriteCommands(reqs, params))
+	})
+
+	t.Run("NOK - write slice to scalar resource", func(t *testing.T) {
+		reqs := []sdkModel.CommandRequest{{
+			DeviceResourceName: "RecipeStep17",
+			Attributes:         map[string]any{NODE: node.String(), INDEXRANGE: "17:18"},
+			Type:               common.ValueTypeFloat64,
+		}}
+		params := []*sdkModel.CommandValue{{DeviceResourceName: "RecipeStep17", Type: common.ValueTypeFloat64, Value: 42.5}}
+
+		s := NewServer("Test", test.NewDSMock(t), nil)
+		assert.Error(t, s.ProcessWriteCommands(reqs, params))
+	})
+}
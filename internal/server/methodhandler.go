@@ -10,7 +10,6 @@ import (
 	"fmt"
 
 	"github.com/edgexfoundry/go-mod-core-contracts/v4/models"
-	"github.com/gopcua/opcua"
 	"github.com/gopcua/opcua/ua"
 )
 
@@ -61,13 +60,15 @@ func (s *Server) makeMethodCall(resource models.DeviceResource, parameters []str
 		InputArguments: inputs,
 	}
 
-	if s.client == nil || s.client.State() == opcua.Closed || s.client.State() == opcua.Disconnected {
-		if err := s.Connect(); err != nil {
-			return nil, fmt.Errorf("Server.makeMethodCall: client not initialized: %s", err)
-		}
+	client, err := s.connectedClient()
+	if err != nil {
+		return nil, fmt.Errorf("Server.makeMethodCall: client not initialized: %s", err)
 	}
 
-	resp, err := s.client.Call(s.client.ctx, request)
+	ctx, cancel := s.requestContext(client)
+	defer cancel()
+
+	resp, err := client.Call(ctx, request)
 	if err != nil {
 		return nil, fmt.Errorf("Server.makeMethodCall: Method call failed: %s", err)
 	}
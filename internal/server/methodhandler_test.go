@@ -37,7 +37,7 @@ func TestDriver_ProcessMethodCall(t *testing.T) {
 
 	t.Run("NOK - device not found", func(t *testing.T) {
 		dsMock := test.NewDSMock(t)
-		s := NewServer("test", dsMock)
+		s := NewServer("test", dsMock, nil)
 		dsMock.On("GetDeviceByName", mock.Anything).Return(models.Device{}, fmt.Errorf("device not found"))
 		_, err := s.ProcessMethodCall("", nil)
 		if err == nil {
@@ -47,7 +47,7 @@ func TestDriver_ProcessMethodCall(t *testing.T) {
 
 	t.Run("NOK - device locked", func(t *testing.T) {
 		dsMock := test.NewDSMock(t)
-		s := NewServer("test", dsMock)
+		s := NewServer("test", dsMock, nil)
 		device := models.Device{AdminState: models.Locked}
 		dsMock.On("GetDeviceByName", mock.Anything).Return(device, nil)
 		_, err := s.ProcessMethodCall("", nil)
@@ -58,7 +58,7 @@ func TestDriver_ProcessMethodCall(t *testing.T) {
 
 	t.Run("NOK - device down", func(t *testing.T) {
 		dsMock := test.NewDSMock(t)
-		s := NewServer("test", dsMock)
+		s := NewServer("test", dsMock, nil)
 		device := models.Device{OperatingState: models.Down}
 		dsMock.On("GetDeviceByName", mock.Anything).Return(device, nil)
 		_, err := s.ProcessMethodCall("", nil)
@@ -69,7 +69,7 @@ func TestDriver_ProcessMethodCall(t *testing.T) {
 
 	t.Run("NOK - method call - method not found", func(t *testing.T) {
 		dsMock := test.NewDSMock(t)
-		s := NewServer("test", dsMock)
+		s := NewServer("test", dsMock, nil)
 		dsMock.On("GetDeviceByName", mock.Anything).Return(okDevice, nil)
 		dsMock.On("DeviceResource", mock.Anything, "TestResource0").Return(models.DeviceResource{}, false)
 		_, err := s.ProcessMethodCall("TestResource0", nil)
@@ -80,7 +80,7 @@ func TestDriver_ProcessMethodCall(t *testing.T) {
 
 	t.Run("NOK - method call - method is hidden", func(t *testing.T) {
 		dsMock := test.NewDSMock(t)
-		s := NewServer("test", dsMock)
+		s := NewServer("test", dsMock, nil)
 		resource := models.DeviceResource{Name: "TestResource1", IsHidden: true}
 		dsMock.On("GetDeviceByName", mock.Anything).Return(okDevice, nil)
 		dsMock.On("DeviceResource", mock.Anything, "").Return(resource, true)
@@ -92,7 +92,7 @@ func TestDriver_ProcessMethodCall(t *testing.T) {
 
 	t.Run("NOK - method call - invalid object node id", func(t *testing.T) {
 		dsMock := test.NewDSMock(t)
-		s := NewServer("test", dsMock)
+		s := NewServer("test", dsMock, nil)
 		resource := models.DeviceResource{
 			Name:       "TestResource1",
 			Attributes: map[string]any{METHOD: "ns=2;s=test"},
@@ -107,7 +107,7 @@ func TestDriver_ProcessMethodCall(t *testing.T) {
 
 	t.Run("NOK - method call - invalid method node id", func(t *testing.T) {
 		dsMock := test.NewDSMock(t)
-		s := NewServer("test", dsMock)
+		s := NewServer("test", dsMock, nil)
 		resource := models.DeviceResource{
 			Name:       "TestResource1",
 			Attributes: map[string]any{OBJECT: "ns=2;s=main"},
@@ -122,7 +122,7 @@ func TestDriver_ProcessMethodCall(t *testing.T) {
 
 	t.Run("NOK - method call - method does not exist", func(t *testing.T) {
 		dsMock := test.NewDSMock(t)
-		s := NewServer("test", dsMock)
+		s := NewServer("test", dsMock, nil)
 		clientMock := gopcuaMocks.NewMockClient(t)
 		s.client = &Client{clientMock, s.context.ctx}
 		resource := models.DeviceResource{
@@ -144,7 +144,7 @@ func TestDriver_ProcessMethodCall(t *testing.T) {
 
 	t.Run("NOK - client connection is closed", func(t *testing.T) {
 		dsMock := test.NewDSMock(t)
-		s := NewServer("test", dsMock)
+		s := NewServer("test", dsMock, nil)
 		clientMock := gopcuaMocks.NewMockClient(t)
 		gopcua.NewClient = func(endpoint string, opts ...opcua.Option) (gopcua.Client, error) {
 			return clientMock, nil
@@ -169,7 +169,7 @@ func TestDriver_ProcessMethodCall(t *testing.T) {
 
 	t.Run("OK - call method from mock server", func(t *testing.T) {
 		dsMock := test.NewDSMock(t)
-		s := NewServer("test", dsMock)
+		s := NewServer("test", dsMock, nil)
 		clientMock := gopcuaMocks.NewMockClient(t)
 		s.client = &Client{clientMock, s.context.ctx}
 		resource := models.DeviceResource{
@@ -183,7 +183,7 @@ func TestDriver_ProcessMethodCall(t *testing.T) {
 		dsMock.On("GetDeviceByName", mock.Anything).Return(okDevice, nil)
 		dsMock.On("DeviceResource", mock.Anything, "").Return(resource, true)
 		clientMock.On("State").Return(opcua.Connected)
-		clientMock.On("Call", mock.Anything, mock.Anything).Return(&ua.CallMethodResult{
+		clientMock.On("Call", test.RequestContext(), mock.Anything).Return(&ua.CallMethodResult{
 			StatusCode:      ua.StatusOK,
 			OutputArguments: []*ua.Variant{ua.MustVariant("4")},
 		}, nil)
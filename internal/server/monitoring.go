@@ -0,0 +1,118 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2025 Schneider Electric
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package server
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gopcua/opcua"
+	"github.com/gopcua/opcua/ua"
+	"github.com/spf13/cast"
+)
+
+// Values of the deadbandType attribute
+const (
+	DeadbandNone     string = "None"
+	DeadbandAbsolute string = "Absolute"
+	DeadbandPercent  string = "Percent"
+)
+
+// monitoredItemRequest returns the request monitoring the node, with the sampling interval
+// in milliseconds, queue size and deadband set by the attributes of the resource
+func monitoredItemRequest(id *ua.NodeID, handle uint32, attrs map[string]any) (*ua.MonitoredItemCreateRequest, error) {
+	req := opcua.NewMonitoredItemCreateRequestWithDefaults(id, ua.AttributeIDValue, handle)
+	params := req.RequestedParameters
+
+	if v, ok := attrs[SAMPLINGINTERVAL]; ok {
+		interval, err := cast.ToFloat64E(v)
+		if err != nil || interval < -1 {
+			return nil, fmt.Errorf("invalid %s %v", SAMPLINGINTERVAL, v)
+		}
+		params.SamplingInterval = interval
+	}
+	if v, ok := attrs[QUEUESIZE]; ok {
+		size, err := cast.ToUint32E(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s %v", QUEUESIZE, v)
+		}
+		params.QueueSize = size
+	}
+	if v, ok := attrs[DISCARDOLDEST]; ok {
+		discard, err := cast.ToBoolE(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s %v", DISCARDOLDEST, v)
+		}
+		params.DiscardOldest = discard
+	}
+
+	filter, err := dataChangeFilter(attrs)
+	if err != nil {
+		return nil, err
+	}
+	if filter != nil {
+		params.Filter = ua.NewExtensionObject(filter)
+	}
+	return req, nil
+}
+
+// dataChangeFilter returns the deadband filter set by the attributes, nil without deadband
+func dataChangeFilter(attrs map[string]any) (*ua.DataChangeFilter, error) {
+	kind, hasKind := attrs[DEADBANDTYPE]
+	value, hasValue := attrs[DEADBANDVALUE]
+	if !hasKind && !hasValue {
+		return nil, nil
+	}
+
+	var deadband ua.DeadbandType
+	switch name := cast.ToString(kind); {
+	case strings.EqualFold(name, DeadbandAbsolute):
+		deadband = ua.DeadbandTypeAbsolute
+	case strings.EqualFold(name, DeadbandPercent):
+		deadband = ua.DeadbandTypePercent
+	case strings.EqualFold(name, DeadbandNone):
+		return nil, nil
+	default:
+		return nil, fmt.Errorf("invalid %s %v, expected %s, %s or %s", DEADBANDTYPE, kind, DeadbandNone, DeadbandAbsolute, DeadbandPercent)
+	}
+
+	if !hasValue {
+		return nil, fmt.Errorf("%s is required with %s %s", DEADBANDVALUE, DEADBANDTYPE, kind)
+	}
+	v, err := cast.ToFloat64E(value)
+	if err != nil || v < 0 || (deadband == ua.DeadbandTypePercent && v > 100) {
+		return nil, fmt.Errorf("invalid %s %v", DEADBANDVALUE, value)
+	}
+
+	return &ua.DataChangeFilter{
+		Trigger:       ua.DataChangeTriggerStatusValue,
+		DeadbandType:  uint32(deadband),
+		DeadbandValue: v,
+	}, nil
+}
+
+// logRevisedParameters logs the monitoring parameters of the resource the server did not grant as requested,
+// revisions of the defaults are only logged at debug level
+func (s *Server) logRevisedParameters(resource string, attrs map[string]any, params *ua.MonitoringParameters,
+	res *ua.MonitoredItemCreateResult) {
+	logf := func(attr string, format string, args ...any) {
+		if _, ok := attrs[attr]; ok {
+			s.sdk.LoggingClient().Infof(format, args...)
+		} else {
+			s.sdk.LoggingClient().Debugf(format, args...)
+		}
+	}
+
+	if res.RevisedSamplingInterval != params.SamplingInterval {
+		logf(SAMPLINGINTERVAL, "[%s] sampling interval of %s revised from %vms to %vms",
+			s.deviceName, resource, params.SamplingInterval, res.RevisedSamplingInterval)
+	}
+	if res.RevisedQueueSize != params.QueueSize {
+		logf(QUEUESIZE, "[%s] queue size of %s revised from %d to %d",
+			s.deviceName, resource, params.QueueSize, res.RevisedQueueSize)
+	}
+}
@@ -0,0 +1,110 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2025 Schneider Electric
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package server
+
+import (
+	"testing"
+
+	"github.com/gopcua/opcua/ua"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMonitoredItemRequest(t *testing.T) {
+	tests := []struct {
+		name       string
+		attrs      map[string]any
+		want       *ua.MonitoringParameters
+		wantFilter *ua.DataChangeFilter
+		wantErr    bool
+	}{
+		{
+			name:  "OK - defaults",
+			attrs: map[string]any{NODE: "ns=2;s=edgex/int32/var0"},
+			want:  &ua.MonitoringParameters{ClientHandle: 1, QueueSize: 10, DiscardOldest: true},
+		},
+		{
+			name: "OK - sampling and queue",
+			attrs: map[string]any{
+				SAMPLINGINTERVAL: 250, QUEUESIZE: "5", DISCARDOLDEST: false,
+			},
+			want: &ua.MonitoringParameters{ClientHandle: 1, SamplingInterval: 250, QueueSize: 5},
+		},
+		{
+			name:       "OK - absolute deadband",
+			attrs:      map[string]any{DEADBANDTYPE: "absolute", DEADBANDVALUE: 0.5},
+			want:       &ua.MonitoringParameters{ClientHandle: 1, QueueSize: 10, DiscardOldest: true},
+			wantFilter: &ua.DataChangeFilter{Trigger: ua.DataChangeTriggerStatusValue, DeadbandType: uint32(ua.DeadbandTypeAbsolute), DeadbandValue: 0.5},
+		},
+		{
+			name:       "OK - percent deadband",
+			attrs:      map[string]any{DEADBANDTYPE: DeadbandPercent, DEADBANDVALUE: "2"},
+			want:       &ua.MonitoringParameters{ClientHandle: 1, QueueSize: 10, DiscardOldest: true},
+			wantFilter: &ua.DataChangeFilter{Trigger: ua.DataChangeTriggerStatusValue, DeadbandType: uint32(ua.DeadbandTypePercent), DeadbandValue: 2},
+		},
+		{
+			name:  "OK - no deadband",
+			attrs: map[string]any{DEADBANDTYPE: DeadbandNone},
+			want:  &ua.MonitoringParameters{ClientHandle: 1, QueueSize: 10, DiscardOldest: true},
+		},
+		{
+			name:    "NOK - invalid sampling interval",
+			attrs:   map[string]any{SAMPLINGINTERVAL: "fast"},
+			wantErr: true,
+		},
+		{
+			name:    "NOK - negative queue size",
+			attrs:   map[string]any{QUEUESIZE: -1},
+			wantErr: true,
+		},
+		{
+			name:    "NOK - invalid discard oldest",
+			attrs:   map[string]any{DISCARDOLDEST: "sometimes"},
+			wantErr: true,
+		},
+		{
+			name:    "NOK - unknown deadband type",
+			attrs:   map[string]any{DEADBANDTYPE: "Relative", DEADBANDVALUE: 1},
+			wantErr: true,
+		},
+		{
+			name:    "NOK - deadband value without type",
+			attrs:   map[string]any{DEADBANDVALUE: 1},
+			wantErr: true,
+		},
+		{
+			name:    "NOK - deadband type without value",
+			attrs:   map[string]any{DEADBANDTYPE: DeadbandAbsolute},
+			wantErr: true,
+		},
+		{
+			name:    "NOK - percent deadband above 100",
+			attrs:   map[string]any{DEADBANDTYPE: DeadbandPercent, DEADBANDVALUE: 150},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := monitoredItemRequest(ua.NewStringNodeID(2, "edgex/int32/var0"), 1, tt.attrs)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			params := got.RequestedParameters
+			if tt.wantFilter == nil {
+				assert.Nil(t, params.Filter)
+			} else {
+				require.NotNil(t, params.Filter)
+				assert.Equal(t, tt.wantFilter, params.Filter.Value)
+			}
+			params.Filter = nil
+			assert.Equal(t, tt.want, params)
+		})
+	}
+}
@@ -0,0 +1,125 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2025 Schneider Electric
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package server
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/gopcua/opcua/id"
+	"github.com/gopcua/opcua/ua"
+)
+
+// readOperationLimits reads the OperationLimits of the server the client is connected to and caches
+// them until the next connection. A server without limits, or failing to return them, is not limited.
+func (s *Server) readOperationLimits() {
+	var maxNodesPerRead uint32
+	defer func() {
+		s.mu.Lock()
+		s.maxNodesPerRead = maxNodesPerRead
+		s.mu.Unlock()
+	}()
+
+	s.mu.Lock()
+	client := s.client
+	s.mu.Unlock()
+	if client == nil {
+		return
+	}
+
+	ctx, cancel := s.requestContext(client)
+	defer cancel()
+	resp, err := client.Read(ctx, &ua.ReadRequest{
+		NodesToRead: []*ua.ReadValueID{{
+			NodeID:      ua.NewNumericNodeID(0, id.Server_ServerCapabilities_OperationLimits_MaxNodesPerRead),
+			AttributeID: ua.AttributeIDValue,
+		}},
+		TimestampsToReturn: ua.TimestampsToReturnNeither,
+	})
+	if err != nil || resp == nil || len(resp.Results) == 0 {
+		s.sdk.LoggingClient().Debugf("[%s] unable to read the operation limits: %v", s.deviceName, err)
+		return
+	}
+
+	if result := resp.Results[0]; result.Status == ua.StatusOK && result.Value != nil {
+		maxNodesPerRead, _ = result.Value.Value().(uint32)
+	}
+	s.sdk.LoggingClient().Debugf("[%s] MaxNodesPerRead of the server: %d", s.deviceName, maxNodesPerRead)
+}
+
+// maxParallelReads bounds the chunks of a read request sent at the same time
+const maxParallelReads = 4
+
+// readChunked sends the read request in chunks of at most MaxNodesPerRead nodes, a few in parallel,
+// and returns the results of the chunks in the order of the nodes of the request. When the server
+// rejects a chunk with BadTooManyOperations, its size is halved until accepted and kept as the limit.
+func (s *Server) readChunked(client *Client, request *ua.ReadRequest) (*ua.ReadResponse, error) {
+	s.mu.Lock()
+	limit := int(s.maxNodesPerRead)
+	s.mu.Unlock()
+
+	if limit == 0 {
+		limit = len(request.NodesToRead)
+	}
+	for {
+		resp, err := s.readChunks(client, request, limit)
+		if !errors.Is(err, ua.StatusBadTooManyOperations) || limit <= 1 {
+			return resp, err
+		}
+
+		limit = (limit + 1) / 2
+		s.sdk.LoggingClient().Debugf("[%s] too many nodes per read, reading at most %d nodes at once", s.deviceName, limit)
+		s.mu.Lock()
+		s.maxNodesPerRead = uint32(limit)
+		s.mu.Unlock()
+	}
+}
+
+// readChunks sends the read request in chunks of at most limit nodes
+func (s *Server) readChunks(client *Client, request *ua.ReadRequest, limit int) (*ua.ReadResponse, error) {
+	if len(request.NodesToRead) <= limit {
+		ctx, cancel := s.requestContext(client)
+		defer cancel()
+		return client.Read(ctx, request)
+	}
+
+	chunks := (len(request.NodesToRead) + limit - 1) / limit
+	responses := make([]*ua.ReadResponse, chunks)
+	errs := make([]error, chunks)
+	sem := make(chan struct{}, maxParallelReads)
+	var wg sync.WaitGroup
+	for i := range chunks {
+		chunk := *request
+		chunk.NodesToRead = request.NodesToRead[i*limit : min((i+1)*limit, len(request.NodesToRead))]
+
+		wg.Add(1)
+		sem <- struct{}{}
+		go func() {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			ctx, cancel := s.requestContext(client)
+			defer cancel()
+			responses[i], errs[i] = client.Read(ctx, &chunk)
+			if errs[i] == nil && len(responses[i].Results) != len(chunk.NodesToRead) {
+				errs[i] = fmt.Errorf("unexpected number of results %d for %d nodes", len(responses[i].Results), len(chunk.NodesToRead))
+			}
+		}()
+	}
+	wg.Wait()
+	if err := errors.Join(errs...); err != nil {
+		return nil, err
+	}
+
+	resp := &ua.ReadResponse{ResponseHeader: responses[0].ResponseHeader}
+	for _, chunk := range responses {
+		resp.Results = append(resp.Results, chunk.Results...)
+	}
+	return resp, nil
+}
@@ -0,0 +1,204 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2025 Schneider Electric
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package server
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/edgexfoundry/device-opcua-go/internal/test"
+	gopcuaMocks "github.com/edgexfoundry/device-opcua-go/pkg/gopcua/mocks"
+	sdkModel "github.com/edgexfoundry/device-sdk-go/v4/pkg/models"
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/common"
+	"github.com/gopcua/opcua"
+	"github.com/gopcua/opcua/id"
+	"github.com/gopcua/opcua/ua"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// maxNodesPerReadResponse returns the response to a read of MaxNodesPerRead, 0 meaning no limit
+func maxNodesPerReadResponse(limit uint32) *ua.ReadResponse {
+	return &ua.ReadResponse{Results: []*ua.DataValue{{Value: ua.MustVariant(limit)}}}
+}
+
+func TestServer_readOperationLimits(t *testing.T) {
+	tests := []struct {
+		name string
+		resp *ua.ReadResponse
+		err  error
+		want uint32
+	}{
+		{name: "OK - limited", resp: maxNodesPerReadResponse(100), want: 100},
+		{name: "OK - not limited", resp: maxNodesPerReadResponse(0)},
+		{name: "OK - limit not exposed", resp: &ua.ReadResponse{Results: []*ua.DataValue{{Status: ua.StatusBadNodeIDUnknown}}}},
+		{name: "OK - read failed", err: fmt.Errorf("error")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewServer("Test", test.NewDSMock(t), nil)
+			s.maxNodesPerRead = 10
+			clientMock := gopcuaMocks.NewMockClient(t)
+			s.client = &Client{clientMock, s.context.ctx}
+			clientMock.On("Read", test.RequestContext(), mock.MatchedBy(func(r *ua.ReadRequest) bool {
+				return r.NodesToRead[0].NodeID.IntID() == id.Server_ServerCapabilities_OperationLimits_MaxNodesPerRead
+			})).Return(tt.resp, tt.err).Once()
+
+			s.readOperationLimits()
+			assert.Equal(t, tt.want, s.maxNodesPerRead)
+		})
+	}
+}
+
+func TestServer_ProcessReadCommands_chunked(t *testing.T) {
+	reqs := make([]sdkModel.CommandRequest, 5)
+	for i := range reqs {
+		reqs[i] = sdkModel.CommandRequest{
+			DeviceResourceName: fmt.Sprintf("Resource%d", i),
+			Attributes:         map[string]any{NODE: fmt.Sprintf("ns=2;i=%d", i)},
+			Type:               common.ValueTypeInt32,
+		}
+	}
+	// the same node read by two resources is read once
+	reqs = append(reqs, sdkModel.CommandRequest{DeviceResourceName: "Alias", Attributes: map[string]any{NODE: "ns=2;i=4"}, Type: common.ValueTypeInt32})
+
+	s := NewServer("Test", test.NewDSMock(t), nil)
+	s.config = &Config{Endpoint: test.Address}
+	s.maxNodesPerRead = 2
+	clientMock := gopcuaMocks.NewMockClient(t)
+	s.client = &Client{clientMock, s.context.ctx}
+	clientMock.On("State").Return(opcua.Connected)
+
+	var mu sync.Mutex
+	var sizes []int
+	clientMock.On("Read", test.RequestContext(), mock.AnythingOfType("*ua.ReadRequest")).Return(
+		func(_ context.Context, r *ua.ReadRequest) *ua.ReadResponse {
+			mu.Lock()
+			sizes = append(sizes, len(r.NodesToRead))
+			mu.Unlock()
+			resp := &ua.ReadResponse{}
+			for _, node := range r.NodesToRead {
+				resp.Results = append(resp.Results, &ua.DataValue{Value: ua.MustVariant(int32(node.NodeID.IntID()))})
+			}
+			return resp
+		}, nil).Times(3)
+
+	got, err := s.ProcessReadCommands(reqs)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []int{2, 2, 1}, sizes)
+	for i := range 5 {
+		assert.Equal(t, int32(i), got[i].Value)
+	}
+	assert.Equal(t, int32(4), got[5].Value)
+}
+
+func TestServer_readChunked(t *testing.T) {
+	t.Run("NOK - chunk failed", func(t *testing.T) {
+		s := NewServer("Test", test.NewDSMock(t), nil)
+		s.maxNodesPerRead = 1
+		clientMock := gopcuaMocks.NewMockClient(t)
+		s.client = &Client{clientMock, s.context.ctx}
+		clientMock.On("Read", test.RequestContext(), mock.MatchedBy(func(r *ua.ReadRequest) bool {
+			return r.NodesToRead[0].NodeID.IntID() == 1
+		})).Return(nil, ua.StatusBadTooManyOperations).Once()
+		clientMock.On("Read", test.RequestContext(), mock.MatchedBy(func(r *ua.ReadRequest) bool {
+			return r.NodesToRead[0].NodeID.IntID() == 2
+		})).Return(&ua.ReadResponse{Results: []*ua.DataValue{{}}}, nil).Once()
+
+		_, err := s.readChunked(s.client, &ua.ReadRequest{NodesToRead: []*ua.ReadValueID{
+			{NodeID: ua.NewNumericNodeID(2, 1)}, {NodeID: ua.NewNumericNodeID(2, 2)},
+		}})
+		assert.ErrorIs(t, err, ua.StatusBadTooManyOperations)
+	})
+
+	t.Run("OK - limit found when not exposed", func(t *testing.T) {
+		s := NewServer("Test", test.NewDSMock(t), nil)
+		clientMock := gopcuaMocks.NewMockClient(t)
+		s.client = &Client{clientMock, s.context.ctx}
+		// the server accepts at most 2 nodes per read without exposing MaxNodesPerRead
+		clientMock.On("Read", test.RequestContext(), mock.MatchedBy(func(r *ua.ReadRequest) bool { return len(r.NodesToRead) > 2 })).
+			Return(nil, ua.StatusBadTooManyOperations)
+		clientMock.On("Read", test.RequestContext(), mock.MatchedBy(func(r *ua.ReadRequest) bool { return len(r.NodesToRead) <= 2 })).
+			Return(func(_ context.Context, r *ua.ReadRequest) *ua.ReadResponse {
+				resp := &ua.ReadResponse{}
+				for _, node := range r.NodesToRead {
+					resp.Results = append(resp.Results, &ua.DataValue{Value: ua.MustVariant(int32(node.NodeID.IntID()))})
+				}
+				return resp
+			}, nil)
+
+		request := &ua.ReadRequest{}
+		for i := range 5 {
+			request.NodesToRead = append(request.NodesToRead, &ua.ReadValueID{NodeID: ua.NewNumericNodeID(2, uint32(i))})
+		}
+		got, err := s.readChunked(s.client, request)
+		require.NoError(t, err)
+		require.Len(t, got.Results, 5)
+		for i := range 5 {
+			assert.Equal(t, int32(i), got.Results[i].Value.Value())
+		}
+		assert.Equal(t, uint32(2), s.maxNodesPerRead)
+	})
+
+	t.Run("OK - connection released during the read", func(t *testing.T) {
+		s := NewServer("Test", test.NewDSMock(t), nil)
+		s.maxNodesPerRead = 1
+		clientMock := gopcuaMocks.NewMockClient(t)
+		client := &Client{clientMock, s.context.ctx}
+		s.client = client
+		// the subscription supervisor releases the connection while the chunks are read
+		clientMock.On("Read", test.RequestContext(), mock.AnythingOfType("*ua.ReadRequest")).
+			Return(func(_ context.Context, r *ua.ReadRequest) *ua.ReadResponse {
+				s.mu.Lock()
+				s.client = nil
+				s.mu.Unlock()
+				return &ua.ReadResponse{Results: []*ua.DataValue{{}}}
+			}, nil).Times(3)
+
+		request := &ua.ReadRequest{}
+		for i := range 3 {
+			request.NodesToRead = append(request.NodesToRead, &ua.ReadValueID{NodeID: ua.NewNumericNodeID(2, uint32(i))})
+		}
+		got, err := s.readChunked(client, request)
+		require.NoError(t, err)
+		assert.Len(t, got.Results, 3)
+	})
+
+	t.Run("OK - parallel chunks bounded", func(t *testing.T) {
+		s := NewServer("Test", test.NewDSMock(t), nil)
+		s.maxNodesPerRead = 1
+		clientMock := gopcuaMocks.NewMockClient(t)
+		s.client = &Client{clientMock, s.context.ctx}
+
+		var mu sync.Mutex
+		var inFlight, maxInFlight int
+		clientMock.On("Read", test.RequestContext(), mock.AnythingOfType("*ua.ReadRequest")).
+			Return(func(_ context.Context, r *ua.ReadRequest) *ua.ReadResponse {
+				mu.Lock()
+				inFlight++
+				maxInFlight = max(maxInFlight, inFlight)
+				mu.Unlock()
+				time.Sleep(10 * time.Millisecond)
+				mu.Lock()
+				inFlight--
+				mu.Unlock()
+				return &ua.ReadResponse{Results: []*ua.DataValue{{}}}
+			}, nil).Times(20)
+
+		request := &ua.ReadRequest{}
+		for i := range 20 {
+			request.NodesToRead = append(request.NodesToRead, &ua.ReadValueID{NodeID: ua.NewNumericNodeID(2, uint32(i))})
+		}
+		_, err := s.readChunked(s.client, request)
+		require.NoError(t, err)
+		assert.LessOrEqual(t, maxInFlight, maxParallelReads)
+	})
+}
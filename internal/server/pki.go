@@ -0,0 +1,277 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2025 Schneider Electric
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package server
+
+import (
+	"bytes"
+	"crypto/sha1" // nolint:gosec
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+
+	"github.com/gopcua/opcua/ua"
+)
+
+// Directories of the certificate store, relative to OPCUAConfig.PKIDir
+const (
+	PKITrustedDir  string = "trusted"
+	PKIRejectedDir string = "rejected"
+	PKIIssuersDir  string = "issuers"
+	PKICRLDir      string = "crl"
+)
+
+// CertificateTrustError reports a server certificate rejected by the certificate store
+type CertificateTrustError struct {
+	Thumbprint string
+	Reason     string
+}
+
+func (e *CertificateTrustError) Error() string {
+	return fmt.Sprintf("server certificate %s is not trusted: %s", e.Thumbprint, e.Reason)
+}
+
+// trustStore validates server certificates against the trusted, issuers and crl
+// directories of the certificate store. The directories are read on every validation
+// so that certificates promoted by an operator are taken into account immediately.
+type trustStore struct {
+	dir string
+}
+
+func newTrustStore(dir string) (*trustStore, error) {
+	for _, sub := range []string{PKITrustedDir, PKIRejectedDir, PKIIssuersDir, PKICRLDir} {
+		if err := os.MkdirAll(filepath.Join(dir, sub), 0700); err != nil {
+			return nil, fmt.Errorf("unable to create certificate store: %v", err)
+		}
+	}
+	return &trustStore{dir: dir}, nil
+}
+
+// verifyServerCertificate validates the certificate of the selected endpoint against the
+// certificate store of the service, when one is configured
+func (s *Server) verifyServerCertificate(ep *ua.EndpointDescription) error {
+	if s.serviceConfig == nil || s.serviceConfig.OPCUA.PKIDir == "" || ep.SecurityMode == ua.MessageSecurityModeNone {
+		return nil
+	}
+
+	store, err := newTrustStore(s.serviceConfig.OPCUA.PKIDir)
+	if err != nil {
+		return err
+	}
+
+	var applicationURI string
+	if ep.Server != nil {
+		applicationURI = ep.Server.ApplicationURI
+	}
+	return store.Verify(ep.ServerCertificate, applicationURI)
+}
+
+// thumbprint returns the SHA-1 thumbprint of a DER certificate, as used by OPC UA
+func thumbprint(der []byte) string {
+	sum := sha1.Sum(der) // nolint:gosec
+	return hex.EncodeToString(sum[:])
+}
+
+// Verify validates the certificate chain sent by the server, its revocation status
+// and that it was issued to the server with the given application URI.
+// Certificates which are not trusted are copied to the rejected directory.
+func (ts *trustStore) Verify(serverCertificate []byte, applicationURI string) error {
+	chain, err := x509.ParseCertificates(serverCertificate)
+	if err != nil || len(chain) == 0 {
+		return ts.reject(serverCertificate, "invalid certificate")
+	}
+	leaf := chain[0]
+
+	now := time.Now()
+	if now.Before(leaf.NotBefore) || now.After(leaf.NotAfter) {
+		return ts.reject(leaf.Raw, fmt.Sprintf("certificate is only valid from %s to %s", leaf.NotBefore, leaf.NotAfter))
+	}
+
+	if applicationURI != "" && !slices.ContainsFunc(leaf.URIs, func(u *url.URL) bool { return u.String() == applicationURI }) {
+		return ts.reject(leaf.Raw, fmt.Sprintf("certificate was not issued to application %s", applicationURI))
+	}
+
+	trusted, err := ts.readCertificates(PKITrustedDir)
+	if err != nil {
+		return err
+	}
+	issuers, err := ts.readCertificates(PKIIssuersDir)
+	if err != nil {
+		return err
+	}
+
+	verified, err := verifyChain(chain, trusted, issuers)
+	if err != nil {
+		return ts.reject(leaf.Raw, err.Error())
+	}
+
+	if err := ts.checkRevocation(verified); err != nil {
+		var revoked *revokedError
+		if !errors.As(err, &revoked) {
+			return err
+		}
+		return ts.reject(leaf.Raw, err.Error())
+	}
+
+	return nil
+}
+
+// verifyChain builds the chain of the leaf certificate and checks that at least
+// one of its certificates is trusted. The issuers are only used to complete the chain.
+func verifyChain(chain, trusted, issuers []*x509.Certificate) ([]*x509.Certificate, error) {
+	leaf := chain[0]
+	isTrusted := func(c *x509.Certificate) bool {
+		return slices.ContainsFunc(trusted, func(t *x509.Certificate) bool { return bytes.Equal(t.Raw, c.Raw) })
+	}
+
+	// self-signed and explicitly trusted application certificates
+	if isTrusted(leaf) {
+		return []*x509.Certificate{leaf}, nil
+	}
+
+	// any trusted certificate is an anchor, trusted intermediate CAs included
+	roots := x509.NewCertPool()
+	intermediates := x509.NewCertPool()
+	for _, c := range trusted {
+		roots.AddCert(c)
+	}
+	for _, c := range slices.Concat(issuers, chain[1:]) {
+		if bytes.Equal(c.RawIssuer, c.RawSubject) {
+			roots.AddCert(c)
+		} else {
+			intermediates.AddCert(c)
+		}
+	}
+
+	chains, err := leaf.Verify(x509.VerifyOptions{
+		Roots:         roots,
+		Intermediates: intermediates,
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageAny},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unknown certificate or issuer: %v", err)
+	}
+
+	for _, c := range chains {
+		if slices.ContainsFunc(c, isTrusted) {
+			return c, nil
+		}
+	}
+	return nil, fmt.Errorf("no certificate of the chain is trusted")
+}
+
+// revokedError reports a certificate of the chain found in a revocation list, or whose
+// issuer has no revocation list
+type revokedError struct {
+	subject string
+	status  ua.StatusCode
+}
+
+func (e *revokedError) Error() string {
+	if e.status == ua.StatusBadCertificateRevocationUnknown {
+		return fmt.Sprintf("no revocation list of the issuer of certificate %s: %v", e.subject, e.status)
+	}
+	return fmt.Sprintf("certificate %s has been revoked: %v", e.subject, e.status)
+}
+
+func (e *revokedError) Unwrap() error {
+	return e.status
+}
+
+// checkRevocation looks for the certificates of the chain in the revocation lists of their issuer,
+// each issuer of the chain must have one
+func (ts *trustStore) checkRevocation(chain []*x509.Certificate) error {
+	lists, err := ts.readRevocationLists()
+	if err != nil {
+		return err
+	}
+
+	for i := 0; i < len(chain)-1; i++ {
+		cert, issuer := chain[i], chain[i+1]
+		found := false
+		for _, crl := range lists {
+			if !bytes.Equal(crl.RawIssuer, issuer.RawSubject) || crl.CheckSignatureFrom(issuer) != nil {
+				continue
+			}
+			found = true
+			for _, revoked := range crl.RevokedCertificateEntries {
+				if revoked.SerialNumber.Cmp(cert.SerialNumber) == 0 {
+					return &revokedError{subject: cert.Subject.String(), status: ua.StatusBadCertificateRevoked}
+				}
+			}
+		}
+		if !found {
+			return &revokedError{subject: cert.Subject.String(), status: ua.StatusBadCertificateRevocationUnknown}
+		}
+	}
+	return nil
+}
+
+// reject copies a certificate which is not trusted to the rejected directory, where it
+// can be reviewed and moved to the trusted directory by an operator, and returns the reason
+func (ts *trustStore) reject(der []byte, reason string) error {
+	tp := thumbprint(der)
+	_ = os.WriteFile(filepath.Join(ts.dir, PKIRejectedDir, tp+".der"), der, 0600)
+	return &CertificateTrustError{Thumbprint: tp, Reason: reason}
+}
+
+func (ts *trustStore) readCertificates(sub string) ([]*x509.Certificate, error) {
+	var certs []*x509.Certificate
+	err := ts.readFiles(sub, func(b []byte) {
+		if parsed, err := x509.ParseCertificates(b); err == nil {
+			certs = append(certs, parsed...)
+		}
+	})
+	return certs, err
+}
+
+func (ts *trustStore) readRevocationLists() ([]*x509.RevocationList, error) {
+	var lists []*x509.RevocationList
+	err := ts.readFiles(PKICRLDir, func(b []byte) {
+		if crl, err := x509.ParseRevocationList(b); err == nil {
+			lists = append(lists, crl)
+		}
+	})
+	return lists, err
+}
+
+// readFiles calls fn with the DER content of every file of a store directory, PEM files are decoded
+func (ts *trustStore) readFiles(sub string, fn func([]byte)) error {
+	dir := filepath.Join(ts.dir, sub)
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return fmt.Errorf("unable to read certificate store: %v", err)
+	}
+
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		b, err := os.ReadFile(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			continue
+		}
+		for {
+			block, rest := pem.Decode(b)
+			if block == nil {
+				break
+			}
+			fn(block.Bytes)
+			b = rest
+		}
+		if len(bytes.TrimSpace(b)) > 0 {
+			fn(b)
+		}
+	}
+	return nil
+}
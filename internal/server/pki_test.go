@@ -0,0 +1,247 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2025 Schneider Electric
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package server
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"errors"
+	"math/big"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/edgexfoundry/device-opcua-go/internal/test"
+	"github.com/gopcua/opcua/ua"
+)
+
+const testServerURI = "urn:test:server"
+
+type testCertificate struct {
+	cert *x509.Certificate
+	key  *rsa.PrivateKey
+}
+
+// issueCertificate creates a certificate signed by parent, or self-signed when parent is nil
+func issueCertificate(t *testing.T, serial int64, isCA bool, notAfter time.Time, parent *testCertificate) *testCertificate {
+	t.Helper()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+	uri, _ := url.Parse(testServerURI)
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(serial),
+		Subject:               pkix.Name{CommonName: "cert-" + big.NewInt(serial).String()},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              notAfter,
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign | x509.KeyUsageCRLSign,
+		BasicConstraintsValid: true,
+		IsCA:                  isCA,
+		URIs:                  []*url.URL{uri},
+	}
+
+	signer, signerKey := template, key
+	if parent != nil {
+		signer, signerKey = parent.cert, parent.key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, signer, &key.PublicKey, signerKey)
+	if err != nil {
+		t.Fatalf("failed to create certificate: %v", err)
+	}
+	cert, _ := x509.ParseCertificate(der)
+	return &testCertificate{cert: cert, key: key}
+}
+
+func writeStoreFile(t *testing.T, dir, sub, name string, b []byte) {
+	t.Helper()
+
+	if err := os.WriteFile(filepath.Join(dir, sub, name), b, 0600); err != nil {
+		t.Fatalf("failed to write %s: %v", name, err)
+	}
+}
+
+func TestTrustStore_Verify(t *testing.T) {
+	validUntil := time.Now().Add(24 * time.Hour)
+	selfSigned := issueCertificate(t, 1, false, validUntil, nil)
+	ca := issueCertificate(t, 2, true, validUntil, nil)
+	issued := issueCertificate(t, 3, false, validUntil, ca)
+	revoked := issueCertificate(t, 4, false, validUntil, ca)
+	expired := issueCertificate(t, 5, false, time.Now().Add(-time.Minute), nil)
+	intermediate := issueCertificate(t, 6, true, validUntil, ca)
+	issuedByIntermediate := issueCertificate(t, 7, false, validUntil, intermediate)
+	caWithoutCRL := issueCertificate(t, 8, true, validUntil, nil)
+	issuedWithoutCRL := issueCertificate(t, 9, false, validUntil, caWithoutCRL)
+
+	crl, err := x509.CreateRevocationList(rand.Reader, &x509.RevocationList{
+		Number:                    big.NewInt(1),
+		RevokedCertificateEntries: []x509.RevocationListEntry{{SerialNumber: revoked.cert.SerialNumber, RevocationTime: time.Now()}},
+	}, ca.cert, ca.key)
+	if err != nil {
+		t.Fatalf("failed to create CRL: %v", err)
+	}
+	intermediateCRL, err := x509.CreateRevocationList(rand.Reader, &x509.RevocationList{Number: big.NewInt(1)},
+		intermediate.cert, intermediate.key)
+	if err != nil {
+		t.Fatalf("failed to create CRL: %v", err)
+	}
+
+	tests := []struct {
+		name           string
+		trusted        []*testCertificate
+		issuers        []*testCertificate
+		cert           *testCertificate
+		applicationURI string
+		wantErr        bool
+		wantRejected   bool
+	}{
+		{
+			name:    "OK - trusted self-signed certificate",
+			trusted: []*testCertificate{selfSigned},
+			cert:    selfSigned,
+		},
+		{
+			name:           "OK - certificate issued by trusted CA",
+			trusted:        []*testCertificate{ca},
+			cert:           issued,
+			applicationURI: testServerURI,
+		},
+		{
+			name:           "OK - certificate issued by trusted intermediate CA",
+			trusted:        []*testCertificate{intermediate},
+			cert:           issuedByIntermediate,
+			applicationURI: testServerURI,
+		},
+		{
+			name:         "NOK - issuer without revocation list",
+			trusted:      []*testCertificate{caWithoutCRL},
+			cert:         issuedWithoutCRL,
+			wantErr:      true,
+			wantRejected: true,
+		},
+		{
+			name:         "NOK - unknown certificate is rejected",
+			cert:         selfSigned,
+			wantErr:      true,
+			wantRejected: true,
+		},
+		{
+			name:         "NOK - issuer known but not trusted",
+			issuers:      []*testCertificate{ca},
+			cert:         issued,
+			wantErr:      true,
+			wantRejected: true,
+		},
+		{
+			name:         "NOK - revoked certificate",
+			trusted:      []*testCertificate{ca},
+			cert:         revoked,
+			wantErr:      true,
+			wantRejected: true,
+		},
+		{
+			name:           "NOK - application URI mismatch",
+			trusted:        []*testCertificate{selfSigned},
+			cert:           selfSigned,
+			applicationURI: "urn:another:server",
+			wantErr:        true,
+			wantRejected:   true,
+		},
+		{
+			name:         "NOK - expired certificate",
+			trusted:      []*testCertificate{expired},
+			cert:         expired,
+			wantErr:      true,
+			wantRejected: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			store, err := newTrustStore(dir)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			for _, c := range tt.trusted {
+				writeStoreFile(t, dir, PKITrustedDir, thumbprint(c.cert.Raw)+".der", c.cert.Raw)
+			}
+			for _, c := range tt.issuers {
+				writeStoreFile(t, dir, PKIIssuersDir, thumbprint(c.cert.Raw)+".der", c.cert.Raw)
+			}
+			writeStoreFile(t, dir, PKICRLDir, "ca.crl", crl)
+			writeStoreFile(t, dir, PKICRLDir, "intermediate.crl", intermediateCRL)
+
+			err = store.Verify(tt.cert.cert.Raw, tt.applicationURI)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("trustStore.Verify() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			var trustErr *CertificateTrustError
+			if err != nil && !errors.As(err, &trustErr) {
+				t.Errorf("expected a CertificateTrustError, got %T", err)
+			}
+
+			_, statErr := os.Stat(filepath.Join(dir, PKIRejectedDir, thumbprint(tt.cert.cert.Raw)+".der"))
+			if rejected := statErr == nil; rejected != tt.wantRejected {
+				t.Errorf("certificate rejected = %v, want %v", rejected, tt.wantRejected)
+			}
+		})
+	}
+}
+
+func TestServer_verifyServerCertificate(t *testing.T) {
+	unknown := issueCertificate(t, 1, false, time.Now().Add(time.Hour), nil)
+
+	tests := []struct {
+		name          string
+		serviceConfig *ServiceConfig
+		endpoint      *ua.EndpointDescription
+		wantErr       bool
+	}{
+		{
+			name:     "OK - no service configuration",
+			endpoint: &ua.EndpointDescription{SecurityMode: ua.MessageSecurityModeSign, ServerCertificate: unknown.cert.Raw},
+		},
+		{
+			name:          "OK - certificate store disabled",
+			serviceConfig: &ServiceConfig{},
+			endpoint:      &ua.EndpointDescription{SecurityMode: ua.MessageSecurityModeSign, ServerCertificate: unknown.cert.Raw},
+		},
+		{
+			name:          "OK - certificate generated without certificate store",
+			serviceConfig: &ServiceConfig{OPCUA: OPCUAConfig{CertificateDir: t.TempDir()}},
+			endpoint:      &ua.EndpointDescription{SecurityMode: ua.MessageSecurityModeSign, ServerCertificate: unknown.cert.Raw},
+		},
+		{
+			name:          "OK - endpoint without security",
+			serviceConfig: &ServiceConfig{OPCUA: OPCUAConfig{PKIDir: t.TempDir()}},
+			endpoint:      &ua.EndpointDescription{SecurityMode: ua.MessageSecurityModeNone},
+		},
+		{
+			name:          "NOK - unknown server certificate",
+			serviceConfig: &ServiceConfig{OPCUA: OPCUAConfig{PKIDir: t.TempDir()}},
+			endpoint: &ua.EndpointDescription{
+				SecurityMode:      ua.MessageSecurityModeSign,
+				ServerCertificate: unknown.cert.Raw,
+				Server:            &ua.ApplicationDescription{ApplicationURI: testServerURI},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewServer("Test", test.NewDSMock(t), tt.serviceConfig)
+			if err := s.verifyServerCertificate(tt.endpoint); (err != nil) != tt.wantErr {
+				t.Errorf("Server.verifyServerCertificate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,97 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2025 Schneider Electric
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package server
+
+import (
+	"fmt"
+	"strings"
+
+	sdkModel "github.com/edgexfoundry/device-sdk-go/v4/pkg/models"
+	"github.com/gopcua/opcua/ua"
+)
+
+// Policies selecting the values sent as readings by the severity of their status code
+const (
+	QualityKeepUncertain = "KeepUncertain" // Good and Uncertain values
+	QualityDropBad       = "DropBad"       // accepted for KeepUncertain
+	QualityKeepAll       = "KeepAll"       // Good, Uncertain and Bad values
+)
+
+// Severities of a status code
+const (
+	QualityGood      = "Good"
+	QualityUncertain = "Uncertain"
+	QualityBad       = "Bad"
+)
+
+// Tags holding the quality of the value of a reading
+const (
+	TagQuality    = "Quality"
+	TagStatusCode = "StatusCode"
+)
+
+// severityMask selects the severity bits of a status code, and subCodeMask the code without its info bits
+const (
+	severityMask = 0xC0000000
+	subCodeMask  = 0xFFFF0000
+)
+
+// quality returns the severity of the status code
+func quality(status ua.StatusCode) string {
+	switch uint32(status) & severityMask {
+	case 0:
+		return QualityGood
+	case 0x40000000:
+		return QualityUncertain
+	default:
+		return QualityBad
+	}
+}
+
+// statusName returns the symbolic name of the status code, such as UncertainLastUsableValue
+func statusName(status ua.StatusCode) string {
+	code := ua.StatusCode(uint32(status) & subCodeMask)
+	if code == ua.StatusOK {
+		return QualityGood
+	}
+	if desc, ok := ua.StatusCodes[code]; ok {
+		return strings.TrimPrefix(desc.Name, "Status")
+	}
+	return fmt.Sprintf("0x%08X", uint32(status))
+}
+
+// qualityAccepts reports whether the policy sends the values with the status code
+func qualityAccepts(policy string, status ua.StatusCode) bool {
+	switch quality(status) {
+	case QualityGood:
+		return true
+	case QualityUncertain:
+		return true
+	default:
+		return policy == QualityKeepAll
+	}
+}
+
+// setQuality tags the reading with the severity and name of the status code of its value
+func setQuality(cv *sdkModel.CommandValue, status ua.StatusCode) {
+	if cv == nil {
+		return
+	}
+	if cv.Tags == nil {
+		cv.Tags = make(map[string]string)
+	}
+	cv.Tags[TagQuality] = quality(status)
+	cv.Tags[TagStatusCode] = statusName(status)
+}
+
+// qualityPolicy returns the quality policy of the device, KeepUncertain until its configuration is loaded
+func (s *Server) qualityPolicy() string {
+	if s.config == nil || s.config.Quality == "" || s.config.Quality == QualityDropBad {
+		return QualityKeepUncertain
+	}
+	return s.config.Quality
+}
@@ -0,0 +1,69 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2025 Schneider Electric
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package server
+
+import (
+	"testing"
+
+	"github.com/gopcua/opcua/ua"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQualityAccepts(t *testing.T) {
+	tests := []struct {
+		name        string
+		status      ua.StatusCode
+		wantQuality string
+		wantName    string
+		wantAccepts map[string]bool
+	}{
+		{
+			name:        "good",
+			status:      ua.StatusOK,
+			wantQuality: QualityGood,
+			wantName:    "Good",
+			wantAccepts: map[string]bool{QualityKeepUncertain: true, QualityDropBad: true, QualityKeepAll: true},
+		},
+		{
+			name:        "good with info bits",
+			status:      ua.StatusGoodClamped | 0x0400,
+			wantQuality: QualityGood,
+			wantName:    "GoodClamped",
+			wantAccepts: map[string]bool{QualityKeepUncertain: true, QualityDropBad: true, QualityKeepAll: true},
+		},
+		{
+			name:        "uncertain",
+			status:      ua.StatusUncertainLastUsableValue,
+			wantQuality: QualityUncertain,
+			wantName:    "UncertainLastUsableValue",
+			wantAccepts: map[string]bool{QualityKeepUncertain: true, QualityDropBad: true, QualityKeepAll: true},
+		},
+		{
+			name:        "bad",
+			status:      ua.StatusBadSensorFailure,
+			wantQuality: QualityBad,
+			wantName:    "BadSensorFailure",
+			wantAccepts: map[string]bool{QualityKeepUncertain: false, QualityDropBad: false, QualityKeepAll: true},
+		},
+		{
+			name:        "unknown bad",
+			status:      ua.StatusCode(0x80FF0000),
+			wantQuality: QualityBad,
+			wantName:    "0x80FF0000",
+			wantAccepts: map[string]bool{QualityKeepUncertain: false, QualityDropBad: false, QualityKeepAll: true},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.wantQuality, quality(tt.status))
+			assert.Equal(t, tt.wantName, statusName(tt.status))
+			for policy, want := range tt.wantAccepts {
+				assert.Equal(t, want, qualityAccepts(policy, tt.status), policy)
+			}
+		})
+	}
+}
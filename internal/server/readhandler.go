@@ -9,46 +9,72 @@
 package server
 
 import (
+	"errors"
 	"fmt"
+	"slices"
+	"time"
 
 	"github.com/edgexfoundry/device-opcua-go/pkg/result"
 	sdkModel "github.com/edgexfoundry/device-sdk-go/v4/pkg/models"
 	"github.com/edgexfoundry/go-mod-core-contracts/v4/clients/logger"
-	"github.com/gopcua/opcua"
 	"github.com/gopcua/opcua/ua"
+	"github.com/spf13/cast"
 )
 
 type ResultToRequest map[int][]int
 
-func createResult(req sdkModel.CommandRequest, variant *ua.Variant, logger logger.LoggingClient) (response *sdkModel.CommandValue) {
+func createResult(req sdkModel.CommandRequest, value *ua.DataValue, clock string, logger logger.LoggingClient) (response *sdkModel.CommandValue) {
+	v := readingValue(value.Value.Value())
+	if _, single, _ := indexRange(req); single {
+		v = scalarElement(v)
+	}
+
 	var err error
-	if response, err = result.NewResult(req, variant.Value()); err != nil {
+	if response, err = result.NewResult(req, v); err != nil {
 		logger.Errorf("Driver.handleReadCommands: Error: %v", err)
+		return response
+	}
+
+	clock, err = timestampClock(req.Attributes, clock)
+	if err != nil {
+		logger.Warnf("Driver.handleReadCommands: resource %s: %v", req.DeviceResourceName, err)
 	}
+	setTimestamps(response, value, clock)
 	return response
 }
 
-func (rr ResultToRequest) buildCommandValues(reqs []sdkModel.CommandRequest, resp *ua.ReadResponse, logger logger.LoggingClient) []*sdkModel.CommandValue {
+// buildCommandValues returns the readings of the values read, whose Origin is set by the clock
+// of the resource or else by the clock of the device. The values rejected by the quality policy
+// are left out of the readings and reported as errors of their resources.
+func (rr ResultToRequest) buildCommandValues(reqs []sdkModel.CommandRequest, resp *ua.ReadResponse, clock, policy string,
+	logger logger.LoggingClient) ([]*sdkModel.CommandValue, error) {
 	responses := make([]*sdkModel.CommandValue, len(reqs))
+	var errs []error
 	for i := 0; i < len(resp.Results); i++ {
-		if resp.Results[i].Status != ua.StatusOK {
-			logger.Debugf("Driver.handleReadCommands: Status not OK: %v", resp.Results[i].Status)
+		status := resp.Results[i].Status
+		variant := resp.Results[i].Value
+		empty := variant == nil || variant.Value() == nil
+		// bad results rarely carry a value, they are errors even when the policy keeps them
+		if !qualityAccepts(policy, status) || (empty && quality(status) == QualityBad) {
+			logger.Debugf("Driver.handleReadCommands: Status not accepted: %v", status)
+			for _, reqIndex := range rr[i] {
+				errs = append(errs, fmt.Errorf("resource %s: %w", reqs[reqIndex].DeviceResourceName, status))
+			}
 			continue
 		}
-
-		variant := resp.Results[i].Value
-		if variant == nil || variant.Value() == nil {
+		if empty {
 			continue
 		}
 
 		if reqIndexes, ok := rr[i]; ok {
 			for _, reqIndex := range reqIndexes {
-				responses[reqIndex] = createResult(reqs[reqIndex], variant, logger)
+				responses[reqIndex] = createResult(reqs[reqIndex], resp.Results[i], clock, logger)
+				setQuality(responses[reqIndex], status)
 			}
 		}
 	}
 
-	return responses
+	return responses, errors.Join(errs...)
 }
 
 func buildNodesToReadRequest(reqs []sdkModel.CommandRequest) (nodesToRead []*ua.ReadValueID, resultToRequest ResultToRequest, err error) {
@@ -66,12 +92,24 @@ func buildNodesToReadRequest(reqs []sdkModel.CommandRequest) (nodesToRead []*ua.
 			return nil, nil, fmt.Errorf("Driver.handleReadCommands: Invalid node id = %v", err)
 		}
 
-		if resultIndex, ok := nodesIdToResultIndex[id.String()]; ok {
+		attribute, property, err := readAttribute(req.Attributes)
+		if err != nil {
+			return nil, nil, fmt.Errorf("Driver.handleReadCommands: resource %s: %v", req.DeviceResourceName, err)
+		}
+
+		numericRange, _, err := indexRange(req)
+		if err != nil {
+			return nil, nil, fmt.Errorf("Driver.handleReadCommands: resource %s: %v", req.DeviceResourceName, err)
+		}
+
+		// the same node is read once per attribute and range, properties are resolved to their own nodes before reading
+		key := fmt.Sprintf("%s/%d/%s/%s", id, attribute, property, numericRange)
+		if resultIndex, ok := nodesIdToResultIndex[key]; ok {
 			resultToRequest[resultIndex] = append(resultToRequest[resultIndex], reqIndex)
 		} else {
-			nodesToRead = append(nodesToRead, &ua.ReadValueID{NodeID: id})
+			nodesToRead = append(nodesToRead, &ua.ReadValueID{NodeID: id, AttributeID: attribute, IndexRange: numericRange})
 			resultIndex = len(nodesToRead) - 1
-			nodesIdToResultIndex[id.String()] = resultIndex
+			nodesIdToResultIndex[key] = resultIndex
 			resultToRequest[resultIndex] = []int{reqIndex}
 		}
 	}
@@ -79,37 +117,124 @@ func buildNodesToReadRequest(reqs []sdkModel.CommandRequest) (nodesToRead []*ua.
 	return nodesToRead, resultToRequest, nil
 }
 
+// readGroup holds the resources of a read command read with the same MaxAge
+type readGroup struct {
+	maxAge          float64
+	indexes         []int // of the requests of the read command
+	reqs            []sdkModel.CommandRequest
+	nodesToRead     []*ua.ReadValueID
+	resultToRequest ResultToRequest
+}
+
+// buildReadGroups splits the requests into groups of the same MaxAge in milliseconds, set by the
+// maxAge attribute of the resource or else by the default, in order of first request
+func buildReadGroups(reqs []sdkModel.CommandRequest, defaultMaxAge float64) ([]*readGroup, error) {
+	var groups []*readGroup
+	byMaxAge := make(map[float64]*readGroup)
+	for i, req := range reqs {
+		maxAge := defaultMaxAge
+		if v, ok := req.Attributes[MAXAGE]; ok {
+			var err error
+			if maxAge, err = cast.ToFloat64E(v); err != nil || maxAge < 0 {
+				return nil, fmt.Errorf("Driver.handleReadCommands: resource %s: invalid %s %v", req.DeviceResourceName, MAXAGE, v)
+			}
+		}
+
+		group, ok := byMaxAge[maxAge]
+		if !ok {
+			group = &readGroup{maxAge: maxAge}
+			byMaxAge[maxAge] = group
+			groups = append(groups, group)
+		}
+		group.indexes = append(group.indexes, i)
+		group.reqs = append(group.reqs, req)
+	}
+
+	for _, group := range groups {
+		var err error
+		if group.nodesToRead, group.resultToRequest, err = buildNodesToReadRequest(group.reqs); err != nil {
+			return nil, err
+		}
+	}
+	return groups, nil
+}
+
+// readMaxAge returns the maximum age of the values read by the device, the default until its configuration is loaded
+func (s *Server) readMaxAge() time.Duration {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.config == nil {
+		return defaultReadMaxAge
+	}
+	return s.config.ReadMaxAgeDuration()
+}
+
 func (s *Server) ProcessReadCommands(reqs []sdkModel.CommandRequest) (responses []*sdkModel.CommandValue, err error) {
 	responses = make([]*sdkModel.CommandValue, len(reqs))
 
-	nodesToRead, resultToRequest, err := buildNodesToReadRequest(reqs)
+	groups, err := buildReadGroups(reqs, float64(s.readMaxAge().Milliseconds()))
 	if err != nil {
 		s.sdk.LoggingClient().Error(err.Error())
 		return responses, err
 	}
 
-	request := &ua.ReadRequest{
-		MaxAge:             2000,
-		NodesToRead:        nodesToRead,
-		TimestampsToReturn: ua.TimestampsToReturnBoth,
+	if len(groups) == 0 {
+		return responses, nil
 	}
 
-	if len(request.NodesToRead) > 0 {
-		if s.client == nil || s.client.State() == opcua.Closed || s.client.State() == opcua.Disconnected {
-			if err := s.Connect(); err != nil {
-				s.sdk.LoggingClient().Errorf("Driver.handleReadCommands: client not initialized: %v", err)
-				return responses, err
-			}
+	client, err := s.connectedClient()
+	if err != nil {
+		s.sdk.LoggingClient().Errorf("Driver.handleReadCommands: client not initialized: %v", err)
+		return responses, err
+	}
+
+	// analog item properties are read from the property nodes
+	ctx, cancel := s.requestContext(client)
+	resolved, err := s.resolveProperties(ctx, client, reqs)
+	cancel()
+	if err != nil {
+		s.sdk.LoggingClient().Errorf("Driver.HandleReadCommands: unable to resolve properties: %v", err)
+		return responses, err
+	}
+	// the configuration is loaded or refreshed by the connection
+	if groups, err = buildReadGroups(resolved, float64(s.readMaxAge().Milliseconds())); err != nil {
+		return responses, err
+	}
+
+	// resources with different MaxAge are read with a request each
+	var errs []error
+	for _, group := range groups {
+		request := &ua.ReadRequest{
+			MaxAge:             group.maxAge,
+			NodesToRead:        group.nodesToRead,
+			TimestampsToReturn: ua.TimestampsToReturnBoth,
 		}
 
-		resp, err := s.client.Read(s.client.ctx, request)
+		// servers limiting the nodes per read are read in chunks
+		resp, err := s.readChunked(client, request)
 		if err != nil {
 			s.sdk.LoggingClient().Errorf("Driver.HandleReadCommands: Handle read commands failed: %v", err)
 			return responses, err
 		}
 
-		responses = resultToRequest.buildCommandValues(reqs, resp, s.sdk.LoggingClient())
+		values, err := group.resultToRequest.buildCommandValues(group.reqs, resp, s.deviceTimestampClock(), s.qualityPolicy(), s.sdk.LoggingClient())
+		for i, reqIndex := range group.indexes {
+			responses[reqIndex] = values[i]
+		}
+		if err != nil {
+			errs = append(errs, err)
+		}
 	}
 
-	return responses, nil
+	err = errors.Join(errs...)
+	if err == nil {
+		return responses, nil
+	}
+	// the rejected resources are left out of the readings, the read fails only without any reading
+	if slices.ContainsFunc(responses, func(cv *sdkModel.CommandValue) bool { return cv != nil }) {
+		s.sdk.LoggingClient().Warnf("[%s] Driver.HandleReadCommands: resources left out: %v", s.deviceName, err)
+		return responses, nil
+	}
+	s.sdk.LoggingClient().Errorf("[%s] Driver.HandleReadCommands: %v", s.deviceName, err)
+	return responses, err
 }
@@ -7,9 +7,12 @@
 package server
 
 import (
+	"errors"
 	"fmt"
 	"reflect"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/edgexfoundry/device-opcua-go/internal/test"
 	"github.com/edgexfoundry/device-opcua-go/pkg/gopcua"
@@ -19,8 +22,11 @@ import (
 	"github.com/edgexfoundry/go-mod-core-contracts/v4/common"
 	"github.com/edgexfoundry/go-mod-core-contracts/v4/models"
 	"github.com/gopcua/opcua"
+	"github.com/gopcua/opcua/id"
 	"github.com/gopcua/opcua/ua"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 )
 
 const (
@@ -44,7 +50,7 @@ func TestDriver_ProcessReadCommands(t *testing.T) {
 		}}
 		dsMock := test.NewDSMock(t)
 		dsMock.On("GetDeviceByName", "Test").Return(models.Device{Protocols: map[string]models.ProtocolProperties{Protocol: {Endpoint: ""}}}, nil)
-		s := NewServer("Test", dsMock)
+		s := NewServer("Test", dsMock, nil)
 
 		_, err := s.ProcessReadCommands(reqs)
 		if err == nil {
@@ -61,13 +67,13 @@ func TestDriver_ProcessReadCommands(t *testing.T) {
 		want := make([]*sdkModel.CommandValue, 1)
 
 		dsMock := test.NewDSMock(t)
-		s := NewServer("TestWithFakeVar", dsMock)
+		s := NewServer("TestWithFakeVar", dsMock, nil)
 		s.config = &Config{Endpoint: test.Address}
 		clientMock := gopcuaMocks.NewMockClient(t)
 
 		readResponse := &ua.ReadResponse{}
 
-		clientMock.On("Read", s.context.ctx, mock.Anything).Return(readResponse, nil)
+		clientMock.On("Read", test.RequestContext(), mock.Anything).Return(readResponse, nil)
 		clientMock.On("State").Return(opcua.Connected)
 		s.client = &Client{clientMock, s.context.ctx}
 
@@ -87,7 +93,7 @@ func TestDriver_ProcessReadCommands(t *testing.T) {
 			Type:               common.ValueTypeInt32,
 		}}
 		dsMock := test.NewDSMock(t)
-		s := NewServer("Test", dsMock)
+		s := NewServer("Test", dsMock, nil)
 		s.config = &Config{Endpoint: test.Address}
 
 		_, err := s.ProcessReadCommands(reqs)
@@ -103,7 +109,7 @@ func TestDriver_ProcessReadCommands(t *testing.T) {
 			Type:               common.ValueTypeInt64,
 		}}
 		dsMock := test.NewDSMock(t)
-		s := NewServer("Test", dsMock)
+		s := NewServer("Test", dsMock, nil)
 		s.config = &Config{Endpoint: test.Address}
 
 		_, err := s.ProcessReadCommands(reqs)
@@ -122,10 +128,10 @@ func TestDriver_ProcessReadCommands(t *testing.T) {
 			DeviceResourceName: "TestVar1",
 			Type:               common.ValueTypeInt32,
 			Value:              int32(5),
-			Tags:               make(map[string]string),
+			Tags:               map[string]string{TagQuality: QualityGood, TagStatusCode: QualityGood},
 		}}
 		dsMock := test.NewDSMock(t)
-		s := NewServer("Test", dsMock)
+		s := NewServer("Test", dsMock, nil)
 		s.config = &Config{Endpoint: test.Address}
 
 		clientMock := gopcuaMocks.NewMockClient(t)
@@ -134,7 +140,7 @@ func TestDriver_ProcessReadCommands(t *testing.T) {
 				{Value: ua.MustVariant(int32(5))},
 			},
 		}
-		clientMock.On("Read", s.context.ctx, mock.Anything).Return(readResponse, nil)
+		clientMock.On("Read", test.RequestContext(), mock.Anything).Return(readResponse, nil)
 		clientMock.On("State").Return(opcua.Connected)
 		s.client = &Client{clientMock, s.context.ctx}
 
@@ -166,16 +172,16 @@ func TestDriver_ProcessReadCommands(t *testing.T) {
 			DeviceResourceName: "TestVar1",
 			Type:               common.ValueTypeInt32,
 			Value:              int32(5),
-			Tags:               make(map[string]string),
+			Tags:               map[string]string{TagQuality: QualityGood, TagStatusCode: QualityGood},
 		}, {
 			DeviceResourceName: "TestVar2",
 			Type:               common.ValueTypeBool,
 			Value:              true,
-			Tags:               make(map[string]string),
+			Tags:               map[string]string{TagQuality: QualityGood, TagStatusCode: QualityGood},
 		}}
 
 		dsMock := test.NewDSMock(t)
-		s := NewServer("Test", dsMock)
+		s := NewServer("Test", dsMock, nil)
 		s.config = &Config{Endpoint: test.Address}
 
 		clientMock := gopcuaMocks.NewMockClient(t)
@@ -185,7 +191,7 @@ func TestDriver_ProcessReadCommands(t *testing.T) {
 				{Value: ua.MustVariant(true)},
 			},
 		}
-		clientMock.On("Read", s.context.ctx, mock.Anything).Return(readResponse, nil)
+		clientMock.On("Read", test.RequestContext(), mock.Anything).Return(readResponse, nil)
 		clientMock.On("State").Return(opcua.Connected)
 		s.client = &Client{clientMock, s.context.ctx}
 
@@ -203,6 +209,125 @@ func TestDriver_ProcessReadCommands(t *testing.T) {
 		}
 	})
 
+	t.Run("OK - bad value left out of the readings", func(t *testing.T) {
+		reqs := []sdkModel.CommandRequest{{
+			DeviceResourceName: "TestVar1",
+			Attributes:         map[string]any{NODE: "ns=2;s=ro_int32"},
+			Type:               common.ValueTypeInt32,
+		}, {
+			DeviceResourceName: "TestVar2",
+			Attributes:         map[string]any{NODE: "ns=2;s=ro_bool"},
+			Type:               common.ValueTypeBool,
+		}}
+
+		dsMock := test.NewDSMock(t)
+		s := NewServer("Test", dsMock, nil)
+		s.config = &Config{Endpoint: test.Address, Quality: QualityDropBad}
+
+		clientMock := gopcuaMocks.NewMockClient(t)
+		readResponse := &ua.ReadResponse{
+			Results: []*ua.DataValue{
+				{Value: ua.MustVariant(int32(5))},
+				{Status: ua.StatusBadSensorFailure},
+			},
+		}
+		clientMock.On("Read", test.RequestContext(), mock.Anything).Return(readResponse, nil).Once()
+		clientMock.On("State").Return(opcua.Connected)
+		s.client = &Client{clientMock, s.context.ctx}
+
+		got, err := s.ProcessReadCommands(reqs)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if got[0] == nil || got[0].Value != int32(5) || got[1] != nil {
+			t.Errorf("Driver.HandleReadCommands() = %v, want [5 <nil>]", got)
+		}
+
+		// without any reading left the read fails
+		readResponse.Results[0].Status = ua.StatusBadNodeIDUnknown
+		clientMock.On("Read", test.RequestContext(), mock.Anything).Return(readResponse, nil).Once()
+
+		_, err = s.ProcessReadCommands(reqs)
+		if !errors.Is(err, ua.StatusBadSensorFailure) || !errors.Is(err, ua.StatusBadNodeIDUnknown) {
+			t.Errorf("expected errors of both resources, got %v", err)
+		}
+	})
+
+	t.Run("OK - read resources with different max age", func(t *testing.T) {
+		reqs := []sdkModel.CommandRequest{{
+			DeviceResourceName: "Interlock",
+			Attributes:         map[string]any{NODE: "ns=2;s=ro_bool", MAXAGE: 0},
+			Type:               common.ValueTypeBool,
+		}, {
+			DeviceResourceName: "TestVar1",
+			Attributes:         map[string]any{NODE: "ns=2;s=ro_int32"},
+			Type:               common.ValueTypeInt32,
+		}}
+
+		dsMock := test.NewDSMock(t)
+		s := NewServer("Test", dsMock, nil)
+		s.config = &Config{Endpoint: test.Address, ReadMaxAge: "10s"}
+
+		clientMock := gopcuaMocks.NewMockClient(t)
+		maxAge := func(ms float64) any {
+			return mock.MatchedBy(func(r *ua.ReadRequest) bool { return r.MaxAge == ms && len(r.NodesToRead) == 1 })
+		}
+		clientMock.On("Read", test.RequestContext(), maxAge(0)).
+			Return(&ua.ReadResponse{Results: []*ua.DataValue{{Value: ua.MustVariant(true)}}}, nil).Once()
+		clientMock.On("Read", test.RequestContext(), maxAge(10000)).
+			Return(&ua.ReadResponse{Results: []*ua.DataValue{{Value: ua.MustVariant(int32(5))}}}, nil).Once()
+		clientMock.On("State").Return(opcua.Connected)
+		s.client = &Client{clientMock, s.context.ctx}
+
+		got, err := s.ProcessReadCommands(reqs)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if got[0].Value != true || got[1].Value != int32(5) {
+			t.Errorf("Driver.HandleReadCommands() = %v, want [true 5]", got)
+		}
+	})
+
+	t.Run("OK - max age of the configuration loaded when connecting", func(t *testing.T) {
+		origGetEndpoints := gopcua.GetEndpoints
+		defer func() { gopcua.GetEndpoints = origGetEndpoints }()
+		test.MockGetEndpoints()
+		newSessionPool(t)
+		clientMock := gopcuaMocks.NewMockClient(t)
+		origNewClient := gopcua.NewClient
+		defer func() { gopcua.NewClient = origNewClient }()
+		gopcua.NewClient = func(endpoint string, opts ...opcua.Option) (gopcua.Client, error) {
+			return clientMock, nil
+		}
+
+		dsMock := test.NewDSMock(t)
+		dsMock.On("GetDeviceByName", "Test").Return(models.Device{
+			Name:       "Test",
+			AdminState: models.Unlocked,
+			Protocols: map[string]models.ProtocolProperties{"opcua": {
+				"Endpoint": test.Address, "Policy": "None", "Mode": "None", "ReadMaxAge": "0s",
+			}},
+		}, nil)
+		clientMock.On("Connect", mock.Anything).Return(nil).Once()
+		clientMock.On("State").Return(opcua.Connected).Maybe()
+		clientMock.On("Read", mock.Anything, mock.MatchedBy(func(r *ua.ReadRequest) bool {
+			return r.NodesToRead[0].NodeID.IntID() == id.Server_ServerCapabilities_OperationLimits_MaxNodesPerRead
+		})).Return(maxNodesPerReadResponse(0), nil).Once()
+		clientMock.On("Read", test.RequestContext(), mock.MatchedBy(func(r *ua.ReadRequest) bool {
+			return r.MaxAge == 0 && r.TimestampsToReturn == ua.TimestampsToReturnBoth
+		})).
+			Return(&ua.ReadResponse{Results: []*ua.DataValue{{Value: ua.MustVariant(int32(5))}}}, nil).Once()
+
+		s := NewServer("Test", dsMock, nil)
+		got, err := s.ProcessReadCommands([]sdkModel.CommandRequest{{
+			DeviceResourceName: "TestVar1",
+			Attributes:         map[string]any{NODE: "ns=2;s=ro_int32"},
+			Type:               common.ValueTypeInt32,
+		}})
+		require.NoError(t, err)
+		assert.Equal(t, int32(5), got[0].Value)
+	})
+
 	t.Run("NOK - error from nil client", func(t *testing.T) {
 		reqs := []sdkModel.CommandRequest{{
 			DeviceResourceName: "TestVar1",
@@ -211,7 +336,7 @@ func TestDriver_ProcessReadCommands(t *testing.T) {
 		}}
 		dsMock := test.NewDSMock(t)
 		dsMock.On("GetDeviceByName", "Test").Return(models.Device{}, fmt.Errorf("error"))
-		s := NewServer("Test", dsMock)
+		s := NewServer("Test", dsMock, nil)
 		s.client = nil
 
 		_, err := s.ProcessReadCommands(reqs)
@@ -229,7 +354,7 @@ func TestDriver_ProcessReadCommands(t *testing.T) {
 		dsMock := test.NewDSMock(t)
 		dsMock.On("GetDeviceByName", "Test").Return(models.Device{Protocols: map[string]models.ProtocolProperties{Protocol: {Endpoint: test.Address}}}, nil)
 
-		s := NewServer("Test", dsMock)
+		s := NewServer("Test", dsMock, nil)
 
 		clientMock := gopcuaMocks.NewMockClient(t)
 		clientMock.On("State").Return(opcua.Disconnected)
@@ -385,6 +510,70 @@ func TestBuildReadRequestOnMissingNodeId(t *testing.T) {
 	}
 }
 
+func TestBuildReadGroups(t *testing.T) {
+	req := func(name, node string, maxAge any) sdkModel.CommandRequest {
+		attrs := map[string]any{NODE: node}
+		if maxAge != nil {
+			attrs[MAXAGE] = maxAge
+		}
+		return sdkModel.CommandRequest{DeviceResourceName: name, Attributes: attrs, Type: common.ValueTypeInt32}
+	}
+
+	tests := []struct {
+		name        string
+		reqs        []sdkModel.CommandRequest
+		wantMaxAges []float64
+		wantIndexes [][]int
+		wantErr     bool
+	}{
+		{
+			name:        "OK - default max age",
+			reqs:        []sdkModel.CommandRequest{req("A", "ns=2;i=1", nil), req("B", "ns=2;i=2", nil)},
+			wantMaxAges: []float64{2000},
+			wantIndexes: [][]int{{0, 1}},
+		},
+		{
+			name: "OK - split by max age",
+			reqs: []sdkModel.CommandRequest{
+				req("Interlock", "ns=2;i=1", 0), req("Level", "ns=2;i=2", nil), req("Pressure", "ns=2;i=3", "0"), req("Ambient", "ns=2;i=4", 60000),
+			},
+			wantMaxAges: []float64{0, 2000, 60000},
+			wantIndexes: [][]int{{0, 2}, {1}, {3}},
+		},
+		{
+			name:    "NOK - negative max age",
+			reqs:    []sdkModel.CommandRequest{req("A", "ns=2;i=1", -1)},
+			wantErr: true,
+		},
+		{
+			name:    "NOK - invalid max age",
+			reqs:    []sdkModel.CommandRequest{req("A", "ns=2;i=1", "fresh")},
+			wantErr: true,
+		},
+		{
+			name:    "NOK - invalid node id in a later group",
+			reqs:    []sdkModel.CommandRequest{req("A", "ns=2;i=1", nil), req("B", "ns=two;i=2", 0)},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			groups, err := buildReadGroups(tt.reqs, 2000)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Len(t, groups, len(tt.wantMaxAges))
+			for i, group := range groups {
+				assert.Equal(t, tt.wantMaxAges[i], group.maxAge)
+				assert.Equal(t, tt.wantIndexes[i], group.indexes)
+				assert.Len(t, group.nodesToRead, len(group.reqs))
+			}
+		})
+	}
+}
+
 func TestBuildCommandValues(t *testing.T) {
 	reqs := []sdkModel.CommandRequest{
 		{
@@ -414,7 +603,10 @@ func TestBuildCommandValues(t *testing.T) {
 			},
 		}
 
-		commandValues := resultToRequest.buildCommandValues(reqs, uaResponse, lc)
+		commandValues, err := resultToRequest.buildCommandValues(reqs, uaResponse, TimestampLocal, QualityDropBad, lc)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
 
 		if len(commandValues) != 3 {
 			t.Fatalf("Expected number of command values 3; got %d;", len(commandValues))
@@ -461,7 +653,10 @@ func TestBuildCommandValues(t *testing.T) {
 			},
 		}
 
-		commandValues := resultToRequest.buildCommandValues(reqs, uaResponse, lc)
+		commandValues, err := resultToRequest.buildCommandValues(reqs, uaResponse, TimestampLocal, QualityDropBad, lc)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
 
 		if len(commandValues) != 3 {
 			t.Fatalf("Expected number of command values 3; got %d;", len(commandValues))
@@ -492,4 +687,66 @@ func TestBuildCommandValues(t *testing.T) {
 		}
 
 	})
+	t.Run("Read with source timestamp", func(t *testing.T) {
+		var resultToRequest ResultToRequest = map[int][]int{0: {0}}
+		source := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+		uaResponse := &ua.ReadResponse{
+			Results: []*ua.DataValue{
+				{
+					Value:           ua.MustVariant(int32(1)),
+					SourceTimestamp: source,
+				},
+			},
+		}
+
+		commandValues, err := resultToRequest.buildCommandValues(reqs[:1], uaResponse, TimestampSource, QualityDropBad, lc)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		if commandValues[0].Origin != source.UnixNano() {
+			t.Fatalf("Expected origin [0] %d; got %d", source.UnixNano(), commandValues[0].Origin)
+		}
+
+		if _, ok := commandValues[0].Tags[TagLocalTimestamp]; !ok {
+			t.Fatalf("Expected tag [0] %s; got %v", TagLocalTimestamp, commandValues[0].Tags)
+		}
+	})
+	t.Run("Read with quality policy", func(t *testing.T) {
+		var resultToRequest ResultToRequest = map[int][]int{0: {0}, 1: {1}, 2: {2}}
+
+		uaResponse := &ua.ReadResponse{
+			Results: []*ua.DataValue{
+				{
+					Value: ua.MustVariant(int32(1)),
+				}, {
+					Value:  ua.MustVariant(int32(2)),
+					Status: ua.StatusUncertainLastUsableValue,
+				}, {
+					Status: ua.StatusBadSensorFailure,
+				},
+			},
+		}
+
+		commandValues, err := resultToRequest.buildCommandValues(reqs, uaResponse, TimestampLocal, QualityDropBad, lc)
+
+		if !errors.Is(err, ua.StatusBadSensorFailure) || !strings.Contains(err.Error(), "Res3") {
+			t.Fatalf("Expected error of resource Res3; got %v", err)
+		}
+
+		if commandValues[1] == nil || commandValues[1].Tags[TagQuality] != QualityUncertain {
+			t.Fatalf("Expected uncertain value [1]; got %v", commandValues[1])
+		}
+
+		if commandValues[1].Tags[TagStatusCode] != "UncertainLastUsableValue" {
+			t.Fatalf("Expected status code tag [1] UncertainLastUsableValue; got %v", commandValues[1].Tags)
+		}
+
+		commandValues, err = resultToRequest.buildCommandValues(reqs, uaResponse, TimestampLocal, QualityKeepUncertain, lc)
+
+		if !errors.Is(err, ua.StatusBadSensorFailure) || commandValues[0] == nil || commandValues[1] == nil || commandValues[2] != nil {
+			t.Fatalf("Expected values [0] and [1] and error of resource Res3; got %v, %v", commandValues, err)
+		}
+	})
 }
@@ -0,0 +1,420 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2025 Schneider Electric
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/edgexfoundry/device-opcua-go/pkg/gopcua"
+	"github.com/gopcua/opcua"
+	"github.com/gopcua/opcua/ua"
+)
+
+// recoverSubscriptions recovers the subscriptions lost with the previous connection, those which
+// cannot be transferred are dropped and created again. It must be called with ps.mu held.
+func (ps *pooledSession) recoverSubscriptions(s *Server) {
+	for params, lost := range ps.lost {
+		delete(ps.lost, params)
+		if ps.recoverSubscription(s, lost) {
+			ps.subscriptions[params] = lost
+		}
+	}
+}
+
+// recoverSubscription transfers a subscription lost with the previous connection to the session,
+// and republishes the notification messages the server kept for it, so that the data changes
+// of the outage are not lost. The subscription keeps its monitored items, and is published by
+// the session from then on. It reports whether the subscription was recovered, a new one has to
+// be created otherwise. It must be called with ps.mu held.
+func (ps *pooledSession) recoverSubscription(s *Server, lost *sharedSubscription) bool {
+	if lost.id == 0 {
+		return false
+	}
+	id := lost.id
+	ctx, cancel := context.WithTimeout(context.Background(), s.requestTimeout())
+	defer cancel()
+
+	available, err := ps.transferSubscription(ctx, id)
+	if err != nil {
+		s.sdk.LoggingClient().Warnf("[%s] unable to transfer subscription %d, the notifications of the outage are lost: %v",
+			s.deviceName, id, err)
+		return false
+	}
+
+	recovered, missed := 0, 0
+	slices.Sort(available)
+	var acks []*ua.SubscriptionAcknowledgement
+	for _, seq := range available {
+		acks = append(acks, &ua.SubscriptionAcknowledgement{SubscriptionID: id, SequenceNumber: seq})
+		msg, err := ps.republish(ctx, id, seq)
+		if err != nil {
+			s.sdk.LoggingClient().Debugf("[%s] unable to republish message %d of subscription %d: %v", s.deviceName, seq, id, err)
+			missed++
+			continue
+		}
+		recovered += lost.redeliver(msg)
+	}
+
+	lost.mu.Lock()
+	lost.client = ps.client
+	// the servers subscribing again count as new references
+	lost.refs = 0
+	if len(available) > 0 {
+		lost.nextSeq = available[len(available)-1] + 1
+	}
+	lost.mu.Unlock()
+	lost.startDispatch()
+	ps.acks = append(ps.acks, acks...)
+	ps.startPublishing()
+	go lost.dropRemoved(s.requestTimeout())
+
+	if missed > 0 {
+		s.sdk.LoggingClient().Warnf("[%s] subscription %d recovered after session loss: %d notifications recovered, %d messages lost",
+			s.deviceName, id, recovered, missed)
+		return true
+	}
+	s.sdk.LoggingClient().Infof("[%s] subscription %d recovered after session loss: %d notifications recovered",
+		s.deviceName, id, recovered)
+	return true
+}
+
+// transferSubscription moves the subscription to the session, and returns the sequence
+// numbers of the notification messages available for republishing
+func (ps *pooledSession) transferSubscription(ctx context.Context, id uint32) ([]uint32, error) {
+	req := &ua.TransferSubscriptionsRequest{SubscriptionIDs: []uint32{id}, SendInitialValues: false}
+
+	var available []uint32
+	err := ps.client.Send(ctx, req, func(v ua.Response) error {
+		res, ok := v.(*ua.TransferSubscriptionsResponse)
+		if !ok {
+			return fmt.Errorf("unexpected response type %T", v)
+		}
+		if len(res.Results) != 1 {
+			return fmt.Errorf("unexpected number of results %d", len(res.Results))
+		}
+		if res.Results[0].StatusCode != ua.StatusOK {
+			return res.Results[0].StatusCode
+		}
+		available = res.Results[0].AvailableSequenceNumbers
+		return nil
+	})
+	return available, err
+}
+
+// republish returns the notification message with the sequence number from the retransmission queue
+func (ps *pooledSession) republish(ctx context.Context, id, seq uint32) (*ua.NotificationMessage, error) {
+	req := &ua.RepublishRequest{SubscriptionID: id, RetransmitSequenceNumber: seq}
+
+	var msg *ua.NotificationMessage
+	err := ps.client.Send(ctx, req, func(v ua.Response) error {
+		res, ok := v.(*ua.RepublishResponse)
+		if !ok {
+			return fmt.Errorf("unexpected response type %T", v)
+		}
+		if res.NotificationMessage == nil {
+			return errors.New("empty notification message")
+		}
+		msg = res.NotificationMessage
+		return nil
+	})
+	return msg, err
+}
+
+// redeliver dispatches the data changes and events of a republished message to the servers which owned
+// the monitored items, and returns the number of notifications delivered
+func (shared *sharedSubscription) redeliver(msg *ua.NotificationMessage) int {
+	delivered := 0
+	for _, data := range msg.NotificationData {
+		if data != nil {
+			delivered += shared.deliver(data.Value)
+		}
+	}
+	return delivered
+}
+
+// dropRemoved deletes the monitored items of the servers removed while the subscription was lost
+func (shared *sharedSubscription) dropRemoved(timeout time.Duration) {
+	shared.mu.Lock()
+	servers := make([]*Server, 0, len(shared.items))
+	for s := range shared.items {
+		servers = append(servers, s)
+	}
+	shared.mu.Unlock()
+
+	var ids []uint32
+	for _, s := range servers {
+		if s.removed() {
+			ids = append(ids, shared.remove(s)...)
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	if err := shared.unmonitor(ctx, ids...); err != nil {
+		shared.lc.Debugf("failed to delete monitored items of removed devices: %v", err)
+	}
+}
+
+// publishes reports whether the session publishes its subscriptions, it must be called with ps.mu held
+func (ps *pooledSession) publishes() bool {
+	for _, shared := range ps.subscriptions {
+		if shared.client != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// publishedIDs returns the ids of the subscriptions published by the session
+func (ps *pooledSession) publishedIDs() []uint32 {
+	ps.mu.Lock()
+	defer ps.mu.Unlock()
+
+	var ids []uint32
+	for _, shared := range ps.subscriptions {
+		if shared.client != nil {
+			ids = append(ids, shared.id)
+		}
+	}
+	return ids
+}
+
+// createSubscription creates the subscription on a session publishing its subscriptions
+func (ps *pooledSession) createSubscription(ctx context.Context, s *Server, shared *sharedSubscription) error {
+	params := shared.params
+	if params.Interval == 0 {
+		params.Interval = opcua.DefaultSubscriptionInterval
+	}
+	if params.LifetimeCount == 0 {
+		params.LifetimeCount = opcua.DefaultSubscriptionLifetimeCount
+	}
+	if params.MaxKeepAliveCount == 0 {
+		params.MaxKeepAliveCount = opcua.DefaultSubscriptionMaxKeepAliveCount
+	}
+	if params.MaxNotificationsPerPublish == 0 {
+		params.MaxNotificationsPerPublish = opcua.DefaultSubscriptionMaxNotificationsPerPublish
+	}
+	req := &ua.CreateSubscriptionRequest{
+		RequestedPublishingInterval: float64(params.Interval / time.Millisecond),
+		RequestedLifetimeCount:      params.LifetimeCount,
+		RequestedMaxKeepAliveCount:  params.MaxKeepAliveCount,
+		PublishingEnabled:           true,
+		MaxNotificationsPerPublish:  params.MaxNotificationsPerPublish,
+		Priority:                    params.Priority,
+	}
+
+	return ps.client.Send(ctx, req, func(v ua.Response) error {
+		res, ok := v.(*ua.CreateSubscriptionResponse)
+		if !ok {
+			return fmt.Errorf("unexpected response type %T", v)
+		}
+		shared.id = res.SubscriptionID
+		shared.client = ps.client
+		s.sdk.LoggingClient().Infof("[%s] subscription %d created: publishing interval %s, lifetime count %d, max keep-alive count %d",
+			s.deviceName, res.SubscriptionID, time.Duration(res.RevisedPublishingInterval)*time.Millisecond,
+			res.RevisedLifetimeCount, res.RevisedMaxKeepAliveCount)
+		return nil
+	})
+}
+
+// createMonitoredItems creates monitored items in a subscription published by the session
+func (shared *sharedSubscription) createMonitoredItems(ctx context.Context, ts ua.TimestampsToReturn,
+	items []*ua.MonitoredItemCreateRequest) (*ua.CreateMonitoredItemsResponse, error) {
+	req := &ua.CreateMonitoredItemsRequest{SubscriptionID: shared.id, TimestampsToReturn: ts, ItemsToCreate: items}
+
+	var res *ua.CreateMonitoredItemsResponse
+	err := shared.client.Send(ctx, req, func(v ua.Response) error {
+		var ok bool
+		if res, ok = v.(*ua.CreateMonitoredItemsResponse); !ok {
+			return fmt.Errorf("unexpected response type %T", v)
+		}
+		if len(res.Results) != len(items) {
+			return fmt.Errorf("unexpected number of results %d", len(res.Results))
+		}
+		return nil
+	})
+	return res, err
+}
+
+// unmonitor deletes the monitored items from the subscription
+func (shared *sharedSubscription) unmonitor(ctx context.Context, ids ...uint32) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	if shared.client == nil {
+		_, err := shared.sub.Unmonitor(ctx, ids...)
+		return err
+	}
+	req := &ua.DeleteMonitoredItemsRequest{SubscriptionID: shared.id, MonitoredItemIDs: ids}
+	return shared.client.Send(ctx, req, func(ua.Response) error { return nil })
+}
+
+// startPublishing starts the publish loop of the session unless it runs, it must be called with ps.mu held
+func (ps *pooledSession) startPublishing() {
+	if ps.stopPublishing != nil {
+		return
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	ps.stopPublishing = cancel
+	go ps.publish(ctx, ps.client)
+}
+
+// stopPublish stops the publish loop of the session, it must be called with ps.mu held
+func (ps *pooledSession) stopPublish() {
+	if ps.stopPublishing != nil {
+		ps.stopPublishing()
+		ps.stopPublishing = nil
+	}
+	ps.acks = nil
+}
+
+// publish sends publish requests for the subscriptions of the session and dispatches the notifications
+// received, until ctx is cancelled or the session has no subscription anymore. On other errors the
+// client is closed, so that the servers reconnect and recover the subscriptions.
+func (ps *pooledSession) publish(ctx context.Context, client gopcua.Client) {
+	for ctx.Err() == nil {
+		ps.mu.Lock()
+		acks := ps.acks
+		ps.acks = nil
+		ps.mu.Unlock()
+		if acks == nil {
+			acks = []*ua.SubscriptionAcknowledgement{}
+		}
+
+		var res *ua.PublishResponse
+		err := client.Send(ctx, &ua.PublishRequest{SubscriptionAcknowledgements: acks}, func(v ua.Response) error {
+			var ok bool
+			if res, ok = v.(*ua.PublishResponse); !ok {
+				return fmt.Errorf("unexpected response type %T", v)
+			}
+			return nil
+		})
+		switch {
+		case err == nil:
+			ps.handlePublish(ctx, client, res)
+		case errors.Is(err, ua.StatusBadTimeout), errors.Is(err, ua.StatusBadTooManyPublishRequests):
+			// no notification within the request timeout, the acknowledgements are sent again
+			ps.mu.Lock()
+			ps.acks = append(acks, ps.acks...)
+			ps.mu.Unlock()
+			select {
+			case <-ctx.Done():
+			case <-time.After(time.Second):
+			}
+		default:
+			ps.mu.Lock()
+			if ctx.Err() != nil {
+				ps.mu.Unlock()
+				return
+			}
+			ps.stopPublish()
+			// the loop is started again with the next subscription published by the session
+			if errors.Is(err, ua.StatusBadNoSubscription) && !ps.publishes() {
+				ps.mu.Unlock()
+				return
+			}
+			// the subscriptions of older connections are not recovered anymore
+			ps.lost = nil
+			ps.mu.Unlock()
+			sessions.mu.Lock()
+			delete(sessions.lost, ps.key)
+			sessions.mu.Unlock()
+
+			// the supervisors of the servers reconnect once the client is closed, recovering the subscriptions
+			closeCtx, cancel := context.WithTimeout(context.Background(), defaultRequestTimeout)
+			_ = client.Close(closeCtx)
+			cancel()
+			return
+		}
+	}
+}
+
+// handlePublish acknowledges the notification message of the publish response and dispatches it,
+// after republishing the messages of the subscription whose publish responses were lost
+func (ps *pooledSession) handlePublish(ctx context.Context, client gopcua.Client, res *ua.PublishResponse) {
+	msg := res.NotificationMessage
+	ps.mu.Lock()
+	var shared *sharedSubscription
+	for _, sub := range ps.subscriptions {
+		if sub.client != nil && sub.id == res.SubscriptionID {
+			shared = sub
+		}
+	}
+	ps.mu.Unlock()
+	if shared == nil || msg == nil {
+		return
+	}
+
+	keepAlive := len(msg.NotificationData) == 0
+	for _, seq := range shared.missing(msg.SequenceNumber, keepAlive) {
+		var lost *ua.NotificationMessage
+		err := client.Send(ctx, &ua.RepublishRequest{SubscriptionID: shared.id, RetransmitSequenceNumber: seq}, func(v ua.Response) error {
+			if r, ok := v.(*ua.RepublishResponse); ok {
+				lost = r.NotificationMessage
+			}
+			return nil
+		})
+		if err != nil || lost == nil {
+			shared.lc.Warnf("unable to republish message %d of subscription %d: %v", seq, shared.id, err)
+			continue
+		}
+		ps.acknowledge(shared.id, seq)
+		shared.notify(lost)
+	}
+	if keepAlive {
+		return
+	}
+	ps.acknowledge(shared.id, msg.SequenceNumber)
+	shared.notify(msg)
+}
+
+// acknowledge sends the acknowledgement of the message with the next publish request
+func (ps *pooledSession) acknowledge(id, seq uint32) {
+	ps.mu.Lock()
+	defer ps.mu.Unlock()
+	ps.acks = append(ps.acks, &ua.SubscriptionAcknowledgement{SubscriptionID: id, SequenceNumber: seq})
+}
+
+// maxMissingMessages bounds the messages republished when publish responses were lost
+const maxMissingMessages = 100
+
+// missing returns the sequence numbers of the messages skipped before the message, a keep-alive
+// message carrying the sequence number of the next message
+func (shared *sharedSubscription) missing(seq uint32, keepAlive bool) []uint32 {
+	shared.mu.Lock()
+	defer shared.mu.Unlock()
+
+	var skipped []uint32
+	if shared.nextSeq != 0 && seq > shared.nextSeq && seq-shared.nextSeq <= maxMissingMessages {
+		for n := shared.nextSeq; n < seq; n++ {
+			skipped = append(skipped, n)
+		}
+	}
+	shared.nextSeq = seq
+	if !keepAlive {
+		shared.nextSeq++
+	}
+	return skipped
+}
+
+// notify hands the notifications of the message to the dispatcher of the subscription
+func (shared *sharedSubscription) notify(msg *ua.NotificationMessage) {
+	for _, data := range msg.NotificationData {
+		if data == nil {
+			continue
+		}
+		select {
+		case shared.notifyCh <- &opcua.PublishNotificationData{SubscriptionID: shared.id, Value: data.Value}:
+		case <-shared.done:
+			return
+		}
+	}
+}
@@ -0,0 +1,247 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2025 Schneider Electric
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package server
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	gopcuaMocks "github.com/edgexfoundry/device-opcua-go/pkg/gopcua/mocks"
+	"github.com/edgexfoundry/device-sdk-go/v4/pkg/interfaces/mocks"
+	sdkModels "github.com/edgexfoundry/device-sdk-go/v4/pkg/models"
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/clients/logger"
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/common"
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/models"
+	"github.com/gopcua/opcua"
+	"github.com/gopcua/opcua/ua"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// mockSend answers the requests matched by the matcher with the response, or fails them with err
+func mockSend(client *gopcuaMocks.MockClient, matcher any, response ua.Response, err error) *mock.Call {
+	return client.On("Send", mock.Anything, matcher, mock.Anything).
+		Return(func(_ context.Context, _ ua.Request, h func(ua.Response) error) error {
+			if err != nil {
+				return err
+			}
+			if response == nil {
+				return nil
+			}
+			return h(response)
+		})
+}
+
+func republishRequest(seq uint32) any {
+	return mock.MatchedBy(func(r *ua.RepublishRequest) bool { return r.RetransmitSequenceNumber == seq })
+}
+
+func dataChangeMessage(handle uint32, value any) *ua.NotificationMessage {
+	return &ua.NotificationMessage{NotificationData: []*ua.ExtensionObject{{
+		Value: &ua.DataChangeNotification{MonitoredItems: []*ua.MonitoredItemNotification{
+			{ClientHandle: handle, Value: &ua.DataValue{Value: ua.MustVariant(value)}},
+		}},
+	}}}
+}
+
+func TestPooledSession_recoverSubscription(t *testing.T) {
+	tests := []struct {
+		name          string
+		setup         func(client *gopcuaMocks.MockClient)
+		wantRecovered bool
+	}{
+		{
+			name: "OK - transferred and republished",
+			setup: func(client *gopcuaMocks.MockClient) {
+				mockSend(client, mock.AnythingOfType("*ua.TransferSubscriptionsRequest"), &ua.TransferSubscriptionsResponse{
+					Results: []*ua.TransferResult{{StatusCode: ua.StatusOK, AvailableSequenceNumbers: []uint32{5, 4}}},
+				}, nil).Once()
+				mockSend(client, republishRequest(4), &ua.RepublishResponse{NotificationMessage: dataChangeMessage(1, int32(42))}, nil).Once()
+				mockSend(client, republishRequest(5), nil, ua.StatusBadMessageNotAvailable).Once()
+				// the session publishes the transferred subscription, acknowledging the messages of the outage
+				published := dataChangeMessage(1, int32(43))
+				published.SequenceNumber = 6
+				mockSend(client, mock.MatchedBy(func(r *ua.PublishRequest) bool { return len(r.SubscriptionAcknowledgements) == 2 }),
+					&ua.PublishResponse{SubscriptionID: 7, NotificationMessage: published}, nil).Once()
+				// the server dropped the subscription still published, the client is closed to reconnect
+				mockSend(client, mock.AnythingOfType("*ua.PublishRequest"), nil, ua.StatusBadNoSubscription).Once()
+				client.On("Close", mock.Anything).Return(nil).Once()
+			},
+			wantRecovered: true,
+		},
+		{
+			name: "NOK - transfer failed",
+			setup: func(client *gopcuaMocks.MockClient) {
+				mockSend(client, mock.AnythingOfType("*ua.TransferSubscriptionsRequest"), &ua.TransferSubscriptionsResponse{
+					Results: []*ua.TransferResult{{StatusCode: ua.StatusBadSubscriptionIDInvalid}},
+				}, nil).Once()
+				client.On("Subscribe", mock.Anything, mock.Anything, mock.Anything).Return(&opcua.Subscription{}, nil).Once()
+			},
+		},
+		{
+			name: "NOK - transfer unsupported",
+			setup: func(client *gopcuaMocks.MockClient) {
+				mockSend(client, mock.AnythingOfType("*ua.TransferSubscriptionsRequest"), nil, ua.StatusBadServiceUnsupported).Once()
+				client.On("Subscribe", mock.Anything, mock.Anything, mock.Anything).Return(&opcua.Subscription{}, nil).Once()
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clientMock := gopcuaMocks.NewMockClient(t)
+			tt.setup(clientMock)
+
+			dsMock := mocks.NewDeviceServiceSDK(t)
+			dsMock.On("LoggingClient").Return(logger.NewMockClient()).Maybe()
+			values := make(chan *sdkModels.AsyncValues, 2)
+			dsMock.On("AsyncValuesChannel").Return(values).Maybe()
+			dsMock.On("DeviceResource", "Test", "TestResource").
+				Return(models.DeviceResource{Name: "TestResource", Properties: models.ResourceProperties{ValueType: common.ValueTypeInt32}}, true).Maybe()
+
+			s := NewServer("Test", dsMock, nil)
+			s.resourceMap[1] = "TestResource"
+			lost := &sharedSubscription{
+				id:       7,
+				sub:      &opcua.Subscription{SubscriptionID: 7},
+				params:   opcua.SubscriptionParameters{Interval: time.Second},
+				lc:       logger.NewMockClient(),
+				handles:  map[uint32]*Server{1: s},
+				items:    map[*Server][]uint32{s: {11}},
+				refs:     1,
+				notifyCh: make(chan *opcua.PublishNotificationData),
+			}
+			ps := &pooledSession{
+				client:        clientMock,
+				subscriptions: make(map[opcua.SubscriptionParameters]*sharedSubscription),
+				lost:          map[opcua.SubscriptionParameters]*sharedSubscription{{Interval: time.Second}: lost},
+			}
+
+			shared, err := ps.subscribe(s, opcua.SubscriptionParameters{Interval: time.Second})
+			require.NoError(t, err)
+			defer shared.cancel()
+			assert.Empty(t, ps.lost)
+
+			if !tt.wantRecovered {
+				assert.NotSame(t, lost, shared)
+				select {
+				case v := <-values:
+					t.Fatalf("unexpected recovered value %v", v)
+				default:
+				}
+				return
+			}
+
+			// the transferred subscription is kept with the monitored items of the server
+			assert.Same(t, lost, shared)
+			assert.Equal(t, 1, shared.refs)
+			assert.True(t, shared.monitors(s))
+			for _, want := range []int32{42, 43} {
+				select {
+				case v := <-values:
+					assert.Equal(t, want, v.CommandValues[0].Value)
+				case <-time.After(time.Second):
+					t.Fatalf("value %d not received", want)
+				}
+			}
+			assert.Eventually(t, func() bool {
+				ps.mu.Lock()
+				defer ps.mu.Unlock()
+				return ps.stopPublishing == nil
+			}, time.Second, 10*time.Millisecond)
+			clientMock.AssertNotCalled(t, "Subscribe", mock.Anything, mock.Anything, mock.Anything)
+			clientMock.AssertNotCalled(t, "Send", mock.Anything, mock.AnythingOfType("*ua.DeleteSubscriptionsRequest"), mock.Anything)
+			clientMock.AssertNotCalled(t, "Send", mock.Anything, mock.AnythingOfType("*ua.CreateSubscriptionRequest"), mock.Anything)
+		})
+	}
+}
+
+func TestSharedSubscription_missing(t *testing.T) {
+	shared := &sharedSubscription{nextSeq: 5}
+	assert.Empty(t, shared.missing(5, false))
+	assert.Equal(t, []uint32{6, 7}, shared.missing(8, false))
+	// a keep-alive message carries the sequence number of the next message
+	assert.Empty(t, shared.missing(9, true))
+	assert.Empty(t, shared.missing(9, false))
+	assert.Equal(t, uint32(10), shared.nextSeq)
+}
+
+func TestPooledSession_publish(t *testing.T) {
+	tests := []struct {
+		name      string
+		err       error
+		published bool
+		wantClose bool
+	}{
+		{
+			name: "OK - no subscription left to publish",
+			err:  ua.StatusBadNoSubscription,
+		},
+		{
+			name:      "NOK - subscription published dropped by the server",
+			err:       ua.StatusBadNoSubscription,
+			published: true,
+			wantClose: true,
+		},
+		{
+			name:      "NOK - session closed",
+			err:       ua.StatusBadSessionClosed,
+			published: true,
+			wantClose: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			newSessionPool(t)
+			clientMock := gopcuaMocks.NewMockClient(t)
+			mockSend(clientMock, mock.AnythingOfType("*ua.PublishRequest"), nil, tt.err).Once()
+			closed := make(chan struct{})
+			if tt.wantClose {
+				clientMock.On("Close", mock.Anything).Return(nil).Once().Run(func(mock.Arguments) { close(closed) })
+			}
+
+			params := opcua.SubscriptionParameters{Interval: time.Second}
+			ps := &pooledSession{
+				key:           "key",
+				client:        clientMock,
+				subscriptions: make(map[opcua.SubscriptionParameters]*sharedSubscription),
+				lost:          map[opcua.SubscriptionParameters]*sharedSubscription{params: {id: 3}},
+			}
+			if tt.published {
+				ps.subscriptions[params] = &sharedSubscription{id: 7, client: clientMock, params: params}
+			}
+			sessions.lost = map[string]map[opcua.SubscriptionParameters]*sharedSubscription{"key": {params: {id: 5}}}
+
+			ps.mu.Lock()
+			ps.startPublishing()
+			ps.mu.Unlock()
+			assert.Eventually(t, func() bool {
+				ps.mu.Lock()
+				defer ps.mu.Unlock()
+				return ps.stopPublishing == nil
+			}, time.Second, 10*time.Millisecond)
+
+			if !tt.wantClose {
+				assert.NotEmpty(t, ps.lost)
+				return
+			}
+			// the client is closed once the lost subscriptions are cleared
+			select {
+			case <-closed:
+			case <-time.After(time.Second):
+				t.Fatal("client not closed")
+			}
+			ps.mu.Lock()
+			assert.Empty(t, ps.lost)
+			ps.mu.Unlock()
+			sessions.mu.Lock()
+			assert.NotContains(t, sessions.lost, "key")
+			sessions.mu.Unlock()
+		})
+	}
+}
@@ -0,0 +1,322 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2025 Schneider Electric
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package server
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/clients/logger"
+	"github.com/gopcua/opcua/uacp"
+)
+
+const (
+	// uacpHeaderSize is the size of an OPC UA Connection Protocol message header
+	uacpHeaderSize = 8
+	// uacpMaxHelloSize bounds the size of the ReverseHello and Hello messages
+	uacpMaxHelloSize = 64 * 1024
+
+	// reverseHelloTimeout is how long a server has to send its ReverseHello once connected
+	reverseHelloTimeout = 10 * time.Second
+	// reverseConnectTimeout is how long a device waits for its server to connect
+	reverseConnectTimeout = 30 * time.Second
+	// reverseConnectionTTL is how long an unused reverse connection is kept
+	reverseConnectionTTL = time.Minute
+)
+
+var (
+	reverseMu       sync.Mutex
+	reverseListener *ReverseListener
+)
+
+// ReverseListener accepts the connections opened by servers configured for reverse connect,
+// and keeps them until the matching device uses them
+type ReverseListener struct {
+	listener net.Listener
+	lc       logger.LoggingClient
+	mu       sync.Mutex
+	pending  []*reverseConn
+	// arrived is closed and replaced every time a connection is queued
+	arrived chan struct{}
+}
+
+// reverseConn is a connection opened by a server, with the ReverseHello it sent
+type reverseConn struct {
+	net.Conn
+	hello    *uacp.ReverseHello
+	received time.Time
+}
+
+// StartReverseListener listens for reverse connections on address, for the devices configured with ReverseConnect
+func StartReverseListener(address string, lc logger.LoggingClient) (*ReverseListener, error) {
+	listener, err := net.Listen("tcp", address)
+	if err != nil {
+		return nil, fmt.Errorf("unable to listen for reverse connections on %s: %v", address, err)
+	}
+
+	l := &ReverseListener{
+		listener: listener,
+		lc:       lc,
+		arrived:  make(chan struct{}),
+	}
+	go l.serve()
+
+	reverseMu.Lock()
+	reverseListener = l
+	reverseMu.Unlock()
+
+	lc.Infof("Listening for reverse connections on %s", listener.Addr())
+	return l, nil
+}
+
+// Addr returns the address the listener accepts reverse connections on
+func (l *ReverseListener) Addr() net.Addr {
+	return l.listener.Addr()
+}
+
+// Close stops accepting reverse connections and closes the pending ones
+func (l *ReverseListener) Close() error {
+	reverseMu.Lock()
+	if reverseListener == l {
+		reverseListener = nil
+	}
+	reverseMu.Unlock()
+
+	err := l.listener.Close()
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for _, rc := range l.pending {
+		rc.Close()
+	}
+	l.pending = nil
+	return err
+}
+
+func (l *ReverseListener) serve() {
+	for {
+		conn, err := l.listener.Accept()
+		if err != nil {
+			return
+		}
+		go l.handle(conn)
+	}
+}
+
+// handle reads the ReverseHello sent by the server and queues the connection
+func (l *ReverseListener) handle(conn net.Conn) {
+	_ = conn.SetReadDeadline(time.Now().Add(reverseHelloTimeout))
+	typ, body, err := readMessage(conn)
+	if err == nil && typ != uacp.MessageTypeReverseHello+"F" {
+		err = fmt.Errorf("unexpected message %s", typ)
+	}
+	hello := new(uacp.ReverseHello)
+	if err == nil {
+		_, err = hello.Decode(body)
+	}
+	if err != nil {
+		l.lc.Warnf("Invalid reverse connection from %s: %v", conn.RemoteAddr(), err)
+		conn.Close()
+		return
+	}
+	_ = conn.SetReadDeadline(time.Time{})
+
+	l.lc.Debugf("Reverse connection from %s, server %s, endpoint %s", conn.RemoteAddr(), hello.ServerURI, hello.EndpointURL)
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.expire()
+	l.pending = append(l.pending, &reverseConn{Conn: conn, hello: hello, received: time.Now()})
+	close(l.arrived)
+	l.arrived = make(chan struct{})
+}
+
+// expire closes the connections not used in time, it must be called with l.mu held
+func (l *ReverseListener) expire() {
+	pending := l.pending[:0]
+	for _, rc := range l.pending {
+		if time.Since(rc.received) > reverseConnectionTTL {
+			rc.Close()
+			continue
+		}
+		pending = append(pending, rc)
+	}
+	l.pending = pending
+}
+
+// take waits for a connection from the server accepted by match, until ctx is done
+func (l *ReverseListener) take(ctx context.Context, match func(*uacp.ReverseHello) bool) (*reverseConn, error) {
+	for {
+		l.mu.Lock()
+		l.expire()
+		for i, rc := range l.pending {
+			if match(rc.hello) {
+				l.pending = append(l.pending[:i], l.pending[i+1:]...)
+				l.mu.Unlock()
+				return rc, nil
+			}
+		}
+		arrived := l.arrived
+		l.mu.Unlock()
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-arrived:
+		}
+	}
+}
+
+// reverseBridge is a loopback listener for the OPC UA client of a device, whose
+// connections are forwarded to the reverse connections opened by the server
+type reverseBridge struct {
+	listener net.Listener
+	endpoint string
+	url      string
+}
+
+// reverseEndpoint returns the local endpoint URL through which the client reaches the server
+// configured for reverse connect. The server is matched by its URI when configured, or by endpoint.
+func (s *Server) reverseEndpoint(endpoint string) (string, error) {
+	reverseMu.Lock()
+	l := reverseListener
+	reverseMu.Unlock()
+	if l == nil {
+		return "", fmt.Errorf("[%s] reverse connect is not enabled, %s.ReverseConnectAddress is not configured",
+			s.deviceName, CustomConfigSectionName)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.bridge != nil && s.bridge.endpoint == endpoint {
+		return s.bridge.url, nil
+	}
+	if s.bridge != nil {
+		s.bridge.listener.Close()
+	}
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return "", err
+	}
+	localURL := url.URL{Scheme: "opc.tcp", Host: listener.Addr().String()}
+	if u, err := url.Parse(endpoint); err == nil {
+		localURL.Path = u.Path
+	}
+
+	serverURI := s.config.ServerURI
+	match := func(hello *uacp.ReverseHello) bool {
+		if serverURI != "" {
+			return hello.ServerURI == serverURI
+		}
+		return hello.EndpointURL == endpoint
+	}
+
+	s.bridge = &reverseBridge{listener: listener, endpoint: endpoint, url: localURL.String()}
+	go s.bridge.serve(s.context.ctx, l, match, s)
+
+	return s.bridge.url, nil
+}
+
+func (b *reverseBridge) serve(ctx context.Context, l *ReverseListener, match func(*uacp.ReverseHello) bool, s *Server) {
+	go func() {
+		<-ctx.Done()
+		b.listener.Close()
+	}()
+
+	for {
+		local, err := b.listener.Accept()
+		if err != nil {
+			return
+		}
+
+		go func() {
+			waitCtx, cancel := context.WithTimeout(ctx, reverseConnectTimeout)
+			defer cancel()
+
+			remote, err := l.take(waitCtx, match)
+			if err != nil {
+				s.sdk.LoggingClient().Warnf("[%s] no reverse connection from server for %s: %v", s.deviceName, b.endpoint, err)
+				local.Close()
+				return
+			}
+			s.sdk.LoggingClient().Debugf("[%s] using reverse connection from %s", s.deviceName, remote.RemoteAddr())
+			pipeReverseConnection(local, remote, b.endpoint)
+		}()
+	}
+}
+
+// pipeReverseConnection forwards the traffic between the client and the server. The Hello of the
+// client carries the loopback URL, it is replaced with the endpoint URL announced by the server.
+func pipeReverseConnection(local net.Conn, remote *reverseConn, endpoint string) {
+	defer local.Close()
+	defer remote.Close()
+
+	typ, body, err := readMessage(local)
+	if err != nil {
+		return
+	}
+	if typ == uacp.MessageTypeHello+"F" {
+		hello := new(uacp.Hello)
+		if _, err := hello.Decode(body); err != nil {
+			return
+		}
+		hello.EndpointURL = remote.hello.EndpointURL
+		if hello.EndpointURL == "" {
+			hello.EndpointURL = endpoint
+		}
+		if body, err = hello.Encode(); err != nil {
+			return
+		}
+	}
+	if err := writeMessage(remote, typ, body); err != nil {
+		return
+	}
+
+	done := make(chan struct{}, 2)
+	go func() {
+		_, _ = io.Copy(remote, local)
+		done <- struct{}{}
+	}()
+	go func() {
+		_, _ = io.Copy(local, remote)
+		done <- struct{}{}
+	}()
+	<-done
+}
+
+// readMessage reads an OPC UA Connection Protocol message, returning its type and chunk type, and its body
+func readMessage(r io.Reader) (string, []byte, error) {
+	header := make([]byte, uacpHeaderSize)
+	if _, err := io.ReadFull(r, header); err != nil {
+		return "", nil, err
+	}
+	size := binary.LittleEndian.Uint32(header[4:])
+	if size < uacpHeaderSize || size > uacpMaxHelloSize {
+		return "", nil, fmt.Errorf("invalid message size %d", size)
+	}
+	body := make([]byte, size-uacpHeaderSize)
+	if _, err := io.ReadFull(r, body); err != nil {
+		return "", nil, err
+	}
+	return string(header[:4]), body, nil
+}
+
+func writeMessage(w io.Writer, typ string, body []byte) error {
+	msg := make([]byte, uacpHeaderSize, uacpHeaderSize+len(body))
+	copy(msg, typ)
+	binary.LittleEndian.PutUint32(msg[4:], uint32(uacpHeaderSize+len(body)))
+	_, err := w.Write(append(msg, body...))
+	return err
+}
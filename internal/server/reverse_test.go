@@ -0,0 +1,134 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2025 Schneider Electric
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package server
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/edgexfoundry/device-opcua-go/internal/test"
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/clients/logger"
+	"github.com/gopcua/opcua/uacp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	reverseServerURI = "urn:test:server"
+	reverseEndpoint  = "opc.tcp://plc:4840/server"
+)
+
+// dialReverse acts as a server configured for reverse connect, opening a connection and sending its ReverseHello
+func dialReverse(t *testing.T, l *ReverseListener, serverURI, endpointURL string) net.Conn {
+	t.Helper()
+
+	conn, err := net.Dial("tcp", l.Addr().String())
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+
+	body, err := (&uacp.ReverseHello{ServerURI: serverURI, EndpointURL: endpointURL}).Encode()
+	require.NoError(t, err)
+	require.NoError(t, writeMessage(conn, uacp.MessageTypeReverseHello+"F", body))
+	return conn
+}
+
+func startReverseListener(t *testing.T) *ReverseListener {
+	t.Helper()
+
+	l, err := StartReverseListener("127.0.0.1:0", logger.NewMockClient())
+	require.NoError(t, err)
+	t.Cleanup(func() { l.Close() })
+	return l
+}
+
+func TestReverseListener_take(t *testing.T) {
+	l := startReverseListener(t)
+	dialReverse(t, l, "urn:other", "opc.tcp://other:4840")
+	dialReverse(t, l, reverseServerURI, reverseEndpoint)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	rc, err := l.take(ctx, func(hello *uacp.ReverseHello) bool { return hello.ServerURI == reverseServerURI })
+	require.NoError(t, err)
+	assert.Equal(t, reverseEndpoint, rc.hello.EndpointURL)
+
+	// a connection is used once, the next one has to be opened by the server
+	ctx, cancel = context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	_, err = l.take(ctx, func(hello *uacp.ReverseHello) bool { return hello.ServerURI == reverseServerURI })
+	assert.Error(t, err)
+}
+
+func TestReverseListener_invalidHello(t *testing.T) {
+	l := startReverseListener(t)
+
+	conn, err := net.Dial("tcp", l.Addr().String())
+	require.NoError(t, err)
+	defer conn.Close()
+	require.NoError(t, writeMessage(conn, uacp.MessageTypeHello+"F", []byte{0}))
+
+	// the listener closes connections which do not start with a ReverseHello
+	_ = conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	_, err = conn.Read(make([]byte, 1))
+	assert.Error(t, err)
+}
+
+func TestServer_reverseEndpoint(t *testing.T) {
+	t.Run("NOK - reverse connect not enabled", func(t *testing.T) {
+		s := NewServer("Test", test.NewDSMock(t), nil)
+		s.config = &Config{Endpoint: reverseEndpoint, ReverseConnect: true}
+		_, err := s.reverseEndpoint(reverseEndpoint)
+		assert.Error(t, err)
+	})
+
+	t.Run("OK - client traffic forwarded to the server", func(t *testing.T) {
+		l := startReverseListener(t)
+		s := NewServer("Test", test.NewDSMock(t), nil)
+		defer s.Cleanup(false)
+		s.config = &Config{Endpoint: reverseEndpoint, ReverseConnect: true, ServerURI: reverseServerURI}
+
+		localURL, err := s.reverseEndpoint(reverseEndpoint)
+		require.NoError(t, err)
+		again, err := s.reverseEndpoint(reverseEndpoint)
+		require.NoError(t, err)
+		assert.Equal(t, localURL, again, "the bridge is reused")
+
+		serverConn := dialReverse(t, l, reverseServerURI, reverseEndpoint)
+
+		// the client dials the local endpoint and sends its Hello
+		_, addr, err := uacp.ResolveEndpoint(context.Background(), localURL)
+		require.NoError(t, err)
+		client, err := net.Dial("tcp", addr.Host)
+		require.NoError(t, err)
+		defer client.Close()
+
+		body, err := (&uacp.Hello{ReceiveBufSize: 0xffff, SendBufSize: 0xffff, EndpointURL: localURL}).Encode()
+		require.NoError(t, err)
+		require.NoError(t, writeMessage(client, uacp.MessageTypeHello+"F", body))
+
+		// the server receives the Hello with its own endpoint URL
+		_ = serverConn.SetReadDeadline(time.Now().Add(5 * time.Second))
+		typ, body, err := readMessage(serverConn)
+		require.NoError(t, err)
+		assert.Equal(t, uacp.MessageTypeHello+"F", typ)
+		hello := new(uacp.Hello)
+		_, err = hello.Decode(body)
+		require.NoError(t, err)
+		assert.Equal(t, reverseEndpoint, hello.EndpointURL)
+
+		// and its answer reaches the client
+		require.NoError(t, writeMessage(serverConn, uacp.MessageTypeAcknowledge+"F", []byte{1, 2, 3, 4}))
+		_ = client.SetReadDeadline(time.Now().Add(5 * time.Second))
+		typ, body, err = readMessage(client)
+		require.NoError(t, err)
+		assert.Equal(t, uacp.MessageTypeAcknowledge+"F", typ)
+		assert.Equal(t, []byte{1, 2, 3, 4}, body)
+	})
+}
@@ -10,6 +10,7 @@ import (
 	"context"
 	"fmt"
 	"sync"
+	"time"
 
 	"github.com/edgexfoundry/device-opcua-go/pkg/gopcua"
 	"github.com/edgexfoundry/device-sdk-go/v4/pkg/interfaces"
@@ -29,33 +30,52 @@ type Client struct {
 }
 
 type Server struct {
-	deviceName  string
-	resourceMap map[uint32]string
-	context     *CancelContext
-	client      *Client
-	config      *Config
-	sdk         interfaces.DeviceServiceSDK
-	mu          sync.Mutex
+	deviceName    string
+	resourceMap   map[uint32]string
+	events        map[uint32]*eventItem
+	context       *CancelContext
+	client        *Client
+	config        *Config
+	serviceConfig *ServiceConfig
+	endpoint      *ua.EndpointDescription
+	active        string
+	session       *pooledSession
+	bridge        *reverseBridge
+	health        models.OperatingState
+	certificate   string
+	tokens        *tokenSource
+	// MaxNodesPerRead of the server the client is connected to, 0 when not limited
+	maxNodesPerRead uint32
+	sdk             interfaces.DeviceServiceSDK
+	mu              sync.Mutex
 }
 
-func NewServer(deviceName string, sdk interfaces.DeviceServiceSDK) *Server {
+func NewServer(deviceName string, sdk interfaces.DeviceServiceSDK, serviceConfig *ServiceConfig) *Server {
 	server := &Server{
-		deviceName:  deviceName,
-		resourceMap: make(map[uint32]string),
-		sdk:         sdk,
+		deviceName:    deviceName,
+		resourceMap:   make(map[uint32]string),
+		serviceConfig: serviceConfig,
+		sdk:           sdk,
 	}
 	server.newContext()
 	return server
 }
 
 func (s *Server) Connect() error {
+	return s.connect(false)
+}
+
+// connect reloads the device configuration and connects to the first available endpoint,
+// starting with the active one, or with the next one when failing over
+func (s *Server) connect(failover bool) error {
 	device, err := s.sdk.GetDeviceByName(s.deviceName)
 	if err != nil {
 		return err
 	}
 
-	if device.AdminState == models.Locked || device.OperatingState == models.Down {
-		return fmt.Errorf("client not started for [%s]: device is locked or down", s.deviceName)
+	// a device reported down by the health monitor still connects, so that it can recover
+	if device.AdminState == models.Locked {
+		return fmt.Errorf("client not started for [%s]: device is locked", s.deviceName)
 	}
 
 	serverConfig, err := NewConfig(device.Protocols["opcua"])
@@ -66,30 +86,19 @@ func (s *Server) Connect() error {
 	s.config = serverConfig
 	s.mu.Unlock()
 
-	if err := s.initClient(); err != nil {
-		return err
-	}
-
-	if err := s.client.Connect(s.client.ctx); err != nil {
-		s.sdk.LoggingClient().Warnf("[%s] failed to connect OPCUA client: %v", s.deviceName, err)
-		return err
-	}
-
-	return nil
+	return s.connectEndpoint(failover)
 }
 
 func (s *Server) Cleanup(recreateContext bool) {
 	s.mu.Lock()
+	// Connection could have been opened from
+	// subscriptionlistener, readhandler, writehandler, or methodhandler
+	release := s.releaseSession()
+	defer release()
 	defer s.mu.Unlock()
 
-	if s.client != nil {
-		// Connection could have been opened from
-		// subscriptionlistener, readhandler, writehandler, or methodhandler
-		if err := s.client.Close(s.client.ctx); err != nil {
-			s.sdk.LoggingClient().Warnf("[%s] failed to close OPCUA client: %v", s.deviceName, err)
-		}
-		s.client = nil
-	}
+	// the bridge of a reverse connection is closed with the context
+	s.bridge = nil
 	if s.context != nil {
 		s.context.cancel()
 		s.context = nil
@@ -99,6 +108,64 @@ func (s *Server) Cleanup(recreateContext bool) {
 	}
 }
 
+// Start runs the subscription listener and the health monitor of the server
+func (s *Server) Start() {
+	s.mu.Lock()
+	ctx := s.context.ctx
+	s.mu.Unlock()
+
+	go s.superviseSubscription(ctx)
+	go s.monitorHealth(ctx)
+}
+
+// Restart closes the connection and starts the server again,
+// so that the device configuration and application certificate are reloaded
+func (s *Server) Restart() {
+	s.Cleanup(true)
+	s.Start()
+}
+
+// removed reports whether the device of the server was removed, its notifications are then dropped
+func (s *Server) removed() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.context == nil
+}
+
+// requestContext returns the context of a request sent with the client, bounded by the request timeout
+func (s *Server) requestContext(client *Client) (context.Context, context.CancelFunc) {
+	return context.WithTimeout(client.ctx, s.requestTimeout())
+}
+
+// connectedClient returns the client of the server, connecting first when the connection is not open.
+// The client is taken under s.mu, so that a command goes on with it when the connection is released.
+func (s *Server) connectedClient() (*Client, error) {
+	s.mu.Lock()
+	client := s.client
+	s.mu.Unlock()
+	if client != nil && client.State() != opcua.Closed && client.State() != opcua.Disconnected {
+		return client, nil
+	}
+
+	if err := s.Connect(); err != nil {
+		return nil, err
+	}
+	s.mu.Lock()
+	client = s.client
+	s.mu.Unlock()
+	if client == nil {
+		return nil, fmt.Errorf("[%s] connection closed", s.deviceName)
+	}
+	return client, nil
+}
+
+func (s *Server) requestTimeout() time.Duration {
+	if s.config == nil {
+		return defaultRequestTimeout
+	}
+	return s.config.RequestTimeoutDuration()
+}
+
 func (s *Server) newContext() {
 	ctxbg := context.Background()
 	ctx, cancel := context.WithCancel(ctxbg) // nolint:gosec
@@ -109,41 +176,74 @@ func (s *Server) newContext() {
 	}
 }
 
-func (s *Server) initClient() error {
+// initClient selects the endpoint and returns the parameters of the connection to it. The server
+// is reached at dialURL, which differs from the configured endpoint with reverse connect.
+func (s *Server) initClient(endpoint, dialURL string) (*connection, error) {
 
-	endpoints, err := gopcua.GetEndpoints(s.context.ctx, s.config.Endpoint)
+	ctx, cancel := context.WithTimeout(s.context.ctx, s.config.ConnectTimeoutDuration())
+	defer cancel()
+	endpoints, err := gopcua.GetEndpoints(ctx, dialURL, opcua.DialTimeout(s.config.ConnectTimeoutDuration()))
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	ep, err := opcua.SelectEndpoint(endpoints, s.config.Policy, ua.MessageSecurityModeFromString(s.config.Mode))
+	ep, err := s.selectEndpoint(endpoints)
 	if err != nil {
 		s.sdk.LoggingClient().Error(err.Error())
-		return fmt.Errorf("[%s] failed to find suitable endpoint", s.deviceName)
+		return nil, fmt.Errorf("[%s] failed to find suitable endpoint", s.deviceName)
 	}
-	ep.EndpointURL = s.config.Endpoint
+	ep.EndpointURL = endpoint
 
-	opts := []opcua.Option{
-		opcua.SecurityPolicy(s.config.Policy),
-		opcua.SecurityModeString(s.config.Mode),
-		opcua.CertificateFile(s.config.CertFile),
-		opcua.PrivateKeyFile(s.config.KeyFile),
-		opcua.AuthAnonymous(),
-		opcua.SecurityFromEndpoint(ep, ua.UserTokenTypeAnonymous),
+	if err := s.verifyServerCertificate(ep); err != nil {
+		s.sdk.LoggingClient().Errorf("[%s] %v", s.deviceName, err)
+		return nil, fmt.Errorf("[%s] %w", s.deviceName, err)
 	}
 
-	client, err := gopcua.NewClient(ep.EndpointURL, opts...)
+	authOpts, err := s.authOptions(ep)
 	if err != nil {
-		return err
+		return nil, err
+	}
+
+	certFile, keyFile := s.certificateFiles()
+	if certFile == "" && ep.SecurityMode != ua.MessageSecurityModeNone {
+		return nil, fmt.Errorf("[%s] no application certificate configured for security policy %s and mode %s",
+			s.deviceName, ep.SecurityPolicyURI, ep.SecurityMode)
+	}
+
+	var certificate string
+	if certFile != "" {
+		cert, err := readApplicationCertificate(certFile, keyFile)
+		if err != nil {
+			return nil, fmt.Errorf("[%s] invalid application certificate: %v", s.deviceName, err)
+		}
+		certificate = cert.Thumbprint
+	}
+
+	opts := []opcua.Option{
+		opcua.SecurityPolicy(ep.SecurityPolicyURI),
+		opcua.SecurityMode(ep.SecurityMode),
+		opcua.CertificateFile(certFile),
+		opcua.PrivateKeyFile(keyFile),
+		opcua.RequestTimeout(s.config.RequestTimeoutDuration()),
+		opcua.SessionTimeout(s.config.SessionTimeoutDuration()),
+		opcua.Lifetime(s.config.SecureChannelLifetimeDuration()),
+		opcua.DialTimeout(s.config.ConnectTimeoutDuration()),
+		// the subscription listener reconnects and recovers the subscriptions itself, as the client
+		// library drops the notifications it republishes when reconnecting
+		opcua.AutoReconnect(false),
 	}
+	opts = append(opts, authOpts...)
 
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	s.client = &Client{
-		client,
-		context.Background(),
-	}
+	s.endpoint = ep
+	s.certificate = certificate
 
-	return nil
+	return &connection{
+		key:            s.sessionKey(ep, certificate),
+		endpointURL:    dialURL,
+		opts:           opts,
+		connectTimeout: s.config.ConnectTimeoutDuration(),
+	}, nil
 }
@@ -14,6 +14,7 @@ import (
 	"github.com/edgexfoundry/device-opcua-go/pkg/gopcua"
 	gopcuaMocks "github.com/edgexfoundry/device-opcua-go/pkg/gopcua/mocks"
 	"github.com/edgexfoundry/device-sdk-go/v4/pkg/interfaces/mocks"
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/clients/logger"
 	"github.com/edgexfoundry/go-mod-core-contracts/v4/models"
 	"github.com/gopcua/opcua"
 	"github.com/stretchr/testify/assert"
@@ -23,7 +24,7 @@ import (
 func TestNewServer(t *testing.T) {
 	t.Run("create server", func(t *testing.T) {
 
-		s := NewServer("test", mocks.NewDeviceServiceSDK(t))
+		s := NewServer("test", mocks.NewDeviceServiceSDK(t), nil)
 		if s == nil {
 			t.Error("NewServer() failed")
 		}
@@ -81,6 +82,7 @@ func TestServer_Connect(t *testing.T) {
 	origGetEndpoints := gopcua.GetEndpoints
 	defer func() { gopcua.GetEndpoints = origGetEndpoints }()
 	test.MockGetEndpoints()
+	newSessionPool(t)
 
 	mockClient := gopcuaMocks.NewMockClient(t)
 	origNewOpcuaClient := gopcua.NewClient
@@ -92,9 +94,13 @@ func TestServer_Connect(t *testing.T) {
 	t.Run("Connect with unlocked and up device", func(t *testing.T) {
 		mockSDK := mocks.NewDeviceServiceSDK(t)
 		mockSDK.On("GetDeviceByName", deviceName).Return(mockDevice, nil)
+		mockSDK.On("LoggingClient").Return(logger.NewMockClient())
 		mockClient.On("Connect", mock.Anything).Return(nil).Once()
+		mockClient.On("Read", mock.Anything, mock.Anything).Return(maxNodesPerReadResponse(0), nil).Once()
+		// the previous client is closed once the new session is acquired
+		mockClient.On("Close", mock.Anything).Return(nil).Once()
 
-		server := NewServer(deviceName, mockSDK)
+		server := NewServer(deviceName, mockSDK, nil)
 		server.client = &Client{mockClient, server.context.ctx}
 		err := server.Connect()
 		assert.NoError(t, err)
@@ -106,22 +112,27 @@ func TestServer_Connect(t *testing.T) {
 		mockSDK := mocks.NewDeviceServiceSDK(t)
 		mockSDK.On("GetDeviceByName", deviceName).Return(mockDevice, nil)
 
-		server := NewServer(deviceName, mockSDK)
+		server := NewServer(deviceName, mockSDK, nil)
 		err := server.Connect()
 		assert.Error(t, err)
-		assert.EqualError(t, err, fmt.Sprintf("client not started for [%s]: device is locked or down", deviceName))
+		assert.EqualError(t, err, fmt.Sprintf("client not started for [%s]: device is locked", deviceName))
 	})
 
 	t.Run("Connect with device in down state", func(t *testing.T) {
 		mockDevice.AdminState = models.Unlocked
 		mockDevice.OperatingState = models.Down
+		newSessionPool(t)
 		mockSDK := mocks.NewDeviceServiceSDK(t)
 		mockSDK.On("GetDeviceByName", deviceName).Return(mockDevice, nil)
+		mockSDK.On("LoggingClient").Return(logger.NewMockClient())
+		mockClient.On("Connect", mock.Anything).Return(nil).Once()
+		mockClient.On("Read", mock.Anything, mock.Anything).Return(maxNodesPerReadResponse(0), nil).Once()
 
-		server := NewServer(deviceName, mockSDK)
+		// the health monitor reports the device up again once connected
+		server := NewServer(deviceName, mockSDK, nil)
 		err := server.Connect()
-		assert.Error(t, err)
-		assert.EqualError(t, err, fmt.Sprintf("client not started for [%s]: device is locked or down", deviceName))
+		assert.NoError(t, err)
+		mockClient.AssertExpectations(t)
 	})
 
 	t.Run("Connect with error getting server config", func(t *testing.T) {
@@ -130,7 +141,7 @@ func TestServer_Connect(t *testing.T) {
 		mockSDK := mocks.NewDeviceServiceSDK(t)
 		mockSDK.On("GetDeviceByName", deviceName).Return(models.Device{}, fmt.Errorf("error getting device"))
 
-		server := NewServer(deviceName, mockSDK)
+		server := NewServer(deviceName, mockSDK, nil)
 		err := server.Connect()
 		assert.Error(t, err)
 		assert.EqualError(t, err, "error getting device")
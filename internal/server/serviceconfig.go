@@ -0,0 +1,69 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2025 Schneider Electric
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package server
+
+import "path/filepath"
+
+// CustomConfigSectionName is the name of the custom configuration section of the device service
+const CustomConfigSectionName = "OPCUA"
+
+// ServiceConfig holds the custom configuration shared by all the devices of the service
+type ServiceConfig struct {
+	OPCUA OPCUAConfig
+}
+
+// OPCUAConfig defines the service level OPC UA settings
+type OPCUAConfig struct {
+	// PKIDir is the root of the certificate store used to validate server certificates,
+	// which also holds the application certificate generated for devices without CertFile
+	// unless CertificateDir is set. Server certificates are not validated when empty.
+	PKIDir string
+	// CertificateDir is where the application certificate is generated for devices without
+	// CertFile, without validating server certificates. Defaults to the own directory of PKIDir.
+	CertificateDir string
+	// ApplicationURI written in the generated application certificate
+	ApplicationURI string
+	// CertificateKeySize is the RSA key size of the generated application certificate: 2048, 3072 or 4096
+	CertificateKeySize int
+	// CertificateValidity is the lifetime of the generated application certificate, e.g. 8760h
+	CertificateValidity string
+	// CertificateCheckInterval is how often the application certificates are checked, e.g. 1h
+	CertificateCheckInterval string
+	// CertificateExpiryWarnings are the durations before expiry at which a warning is logged, e.g. 720h
+	CertificateExpiryWarnings []string
+	// ReverseConnectAddress is where servers configured for reverse connect open their
+	// connections, e.g. :4843. Reverse connect is disabled when empty.
+	ReverseConnectAddress string
+}
+
+// GeneratesCertificate reports whether an application certificate is generated for devices without CertFile
+func (c OPCUAConfig) GeneratesCertificate() bool {
+	return c.ownDir() != ""
+}
+
+// ownDir returns the directory of the generated application certificate
+func (c OPCUAConfig) ownDir() string {
+	if c.CertificateDir != "" {
+		return c.CertificateDir
+	}
+	if c.PKIDir != "" {
+		return filepath.Join(c.PKIDir, PKIOwnDir)
+	}
+	return ""
+}
+
+// UpdateFromRaw updates the service's full configuration from raw data received from
+// the Service Provider.
+func (sc *ServiceConfig) UpdateFromRaw(rawConfig any) bool {
+	configuration, ok := rawConfig.(*ServiceConfig)
+	if !ok {
+		return false
+	}
+
+	*sc = *configuration
+	return true
+}
@@ -0,0 +1,500 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2025 Schneider Electric
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package server
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/edgexfoundry/device-opcua-go/pkg/gopcua"
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/clients/logger"
+	"github.com/gopcua/opcua"
+	"github.com/gopcua/opcua/ua"
+)
+
+// sessions shares one secure channel and session between the devices configured
+// with the same endpoint, security and user identity
+var sessions = &sessionPool{sessions: make(map[string]*pooledSession)}
+
+type sessionPool struct {
+	mu       sync.Mutex
+	sessions map[string]*pooledSession
+	// lost keeps the subscriptions of sessions closed after losing their connection,
+	// until a session with the same key recovers them
+	lost map[string]map[opcua.SubscriptionParameters]*sharedSubscription
+}
+
+// pooledSession is a connected client, used by one or more servers
+type pooledSession struct {
+	key    string
+	mu     sync.Mutex
+	client gopcua.Client
+	// users reference counts the servers using the session, the owner refreshes its issued token
+	users         map[*Server]bool
+	owner         *Server
+	subscriptions map[opcua.SubscriptionParameters]*sharedSubscription
+	// lost are the subscriptions of the previous connection, transferred to the session when subscribing
+	lost map[opcua.SubscriptionParameters]*sharedSubscription
+	// the session publishes the transferred subscriptions itself, acknowledging the messages received
+	stopPublishing context.CancelFunc
+	acks           []*ua.SubscriptionAcknowledgement
+}
+
+// connection holds the parameters used to create the client of a session
+type connection struct {
+	key string
+	// endpointURL is the URL the client dials, the local bridge with reverse connect
+	endpointURL    string
+	opts           []opcua.Option
+	connectTimeout time.Duration
+}
+
+// sessionKey identifies the connection parameters which allow devices to share a session
+func (s *Server) sessionKey(ep *ua.EndpointDescription, certificate string) string {
+	return strings.Join([]string{
+		ep.EndpointURL, ep.SecurityPolicyURI, ep.SecurityMode.String(), certificate,
+		s.config.AuthMode, s.config.SecretName, s.config.UserCertFile, s.config.TokenEndpoint, s.config.TokenScope,
+	}, "|")
+}
+
+// acquire returns the session for the connection, creating and connecting the client
+// if no other server uses it yet. It reports whether the server owns the session.
+func (p *sessionPool) acquire(s *Server, conn *connection) (*pooledSession, bool, error) {
+	p.mu.Lock()
+	ps, ok := p.sessions[conn.key]
+	if !ok {
+		ps = &pooledSession{
+			key:           conn.key,
+			users:         make(map[*Server]bool),
+			subscriptions: make(map[opcua.SubscriptionParameters]*sharedSubscription),
+			lost:          p.lost[conn.key],
+		}
+		p.sessions[conn.key] = ps
+		delete(p.lost, conn.key)
+	}
+	ps.users[s] = true
+	p.mu.Unlock()
+
+	// servers acquiring the session at the same time wait for the first one to connect
+	ps.mu.Lock()
+	defer ps.mu.Unlock()
+
+	if ps.client != nil && ps.client.State() == opcua.Closed {
+		// the subscriptions were lost with the connection, the servers recover them
+		ps.stopPublish()
+		ps.loseSubscriptions()
+		ps.client = nil
+	}
+	if ps.client != nil {
+		return ps, false, nil
+	}
+
+	client, err := gopcua.NewClient(conn.endpointURL, conn.opts...)
+	if err == nil {
+		ctx, cancel := context.WithTimeout(context.Background(), conn.connectTimeout)
+		err = client.Connect(ctx)
+		cancel()
+		if err != nil {
+			s.sdk.LoggingClient().Warnf("[%s] failed to connect OPCUA client: %v", s.deviceName, err)
+		}
+	}
+	if err != nil {
+		p.mu.Lock()
+		p.removeUser(ps, s)
+		p.mu.Unlock()
+		return nil, false, err
+	}
+
+	ps.client = client
+	p.mu.Lock()
+	ps.owner = s
+	p.mu.Unlock()
+	return ps, true, nil
+}
+
+// release removes the server from the users of the session, and closes the session when the
+// last server leaves. The requests closing it are bounded by timeout and sent without ps.mu held.
+func (p *sessionPool) release(ps *pooledSession, s *Server, timeout time.Duration) error {
+	p.mu.Lock()
+	last := p.removeUser(ps, s)
+
+	var next *Server
+	if !last && ps.owner == s {
+		for user := range ps.users {
+			next = user
+			break
+		}
+		ps.owner = next
+	}
+	p.mu.Unlock()
+
+	if next != nil {
+		// the new owner locks its own state, which the caller might hold for s
+		go next.takeOverSession(ps)
+	}
+	if !last {
+		return nil
+	}
+
+	ps.mu.Lock()
+	ps.stopPublish()
+	if ps.client != nil && ps.client.State() == opcua.Closed {
+		// keep the lost subscriptions for the next session, when the device reconnects
+		ps.loseSubscriptions()
+	}
+	if len(ps.lost) > 0 {
+		p.mu.Lock()
+		if p.lost == nil {
+			p.lost = make(map[string]map[opcua.SubscriptionParameters]*sharedSubscription)
+		}
+		p.lost[ps.key] = ps.lost
+		p.mu.Unlock()
+	}
+	subs := make([]*sharedSubscription, 0, len(ps.subscriptions))
+	for params, sub := range ps.subscriptions {
+		subs = append(subs, sub)
+		delete(ps.subscriptions, params)
+	}
+	client := ps.client
+	ps.client = nil
+	ps.mu.Unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	for _, sub := range subs {
+		sub.stop(ctx)
+	}
+	if client == nil {
+		return nil
+	}
+	return client.Close(ctx)
+}
+
+// removeUser must be called with p.mu held, it reports whether the session is no longer used
+func (p *sessionPool) removeUser(ps *pooledSession, s *Server) bool {
+	delete(ps.users, s)
+	if len(ps.users) > 0 {
+		return false
+	}
+	if p.sessions[ps.key] == ps {
+		delete(p.sessions, ps.key)
+	}
+	return true
+}
+
+// loseSubscriptions moves the subscriptions of a closed client to the lost ones,
+// it must be called with ps.mu held
+func (ps *pooledSession) loseSubscriptions() {
+	if len(ps.subscriptions) == 0 {
+		return
+	}
+	if ps.lost == nil {
+		ps.lost = make(map[opcua.SubscriptionParameters]*sharedSubscription)
+	}
+	for params, shared := range ps.subscriptions {
+		// the subscription cannot be cancelled without a connection, only its dispatcher stops
+		shared.cancel()
+		ps.lost[params] = shared
+		delete(ps.subscriptions, params)
+	}
+}
+
+// takeOverSession makes the server responsible for the session after its owner left
+func (s *Server) takeOverSession(ps *pooledSession) {
+	s.mu.Lock()
+	client := s.client
+	shared := s.session == ps && client != nil
+	issued := s.config != nil && s.config.AuthMode == AuthModeIssued
+	ctx := s.context
+	s.mu.Unlock()
+
+	if shared && issued && ctx != nil {
+		s.refreshIssuedToken(ctx.ctx, client)
+	}
+}
+
+// releaseSession detaches the session of the server, it must be called with s.mu held. The returned
+// function leaves the session, closing its client when the server was the last user, and must be
+// called once s.mu is released so that no request is sent with the lock held.
+func (s *Server) releaseSession() func() {
+	client, session := s.client, s.session
+	s.client, s.session = nil, nil
+	if client == nil {
+		return func() {}
+	}
+	timeout := s.requestTimeout()
+
+	return func() {
+		var err error
+		if session != nil {
+			err = sessions.release(session, s, timeout)
+		} else {
+			ctx, cancel := context.WithTimeout(client.ctx, timeout)
+			err = client.Close(ctx)
+			cancel()
+		}
+		if err != nil {
+			s.sdk.LoggingClient().Warnf("[%s] failed to close OPCUA client: %v", s.deviceName, err)
+		}
+	}
+}
+
+// sharedSubscription is a subscription of a session, whose monitored items belong to several servers
+type sharedSubscription struct {
+	id  uint32
+	sub *opcua.Subscription
+	// client is set when the session publishes the subscription rather than the library
+	client   gopcua.Client
+	params   opcua.SubscriptionParameters
+	lc       logger.LoggingClient
+	cancel   context.CancelFunc
+	done     <-chan struct{}
+	mu       sync.Mutex
+	next     uint32
+	nextSeq  uint32
+	handles  map[uint32]*Server
+	items    map[*Server][]uint32
+	refs     int
+	notifyCh chan *opcua.PublishNotificationData
+}
+
+// subscribe returns the subscription of the session with the parameters,
+// creating it for the first server
+func (ps *pooledSession) subscribe(s *Server, params opcua.SubscriptionParameters) (*sharedSubscription, error) {
+	ps.mu.Lock()
+	defer ps.mu.Unlock()
+
+	if ps.client == nil {
+		return nil, fmt.Errorf("[%s] session closed", s.deviceName)
+	}
+
+	// the subscriptions of the previous connection are recovered before any is created
+	ps.recoverSubscriptions(s)
+
+	if shared, ok := ps.subscriptions[params]; ok {
+		shared.mu.Lock()
+		shared.refs++
+		shared.mu.Unlock()
+		return shared, nil
+	}
+
+	shared := &sharedSubscription{
+		params:   params,
+		lc:       s.sdk.LoggingClient(),
+		handles:  make(map[uint32]*Server),
+		items:    make(map[*Server][]uint32),
+		refs:     1,
+		notifyCh: make(chan *opcua.PublishNotificationData),
+	}
+	if ps.publishes() {
+		// the library drops the notifications of the subscriptions it did not create,
+		// the session publishes the new subscription together with the transferred ones
+		ctx, cancel := context.WithTimeout(context.Background(), s.requestTimeout())
+		err := ps.createSubscription(ctx, s, shared)
+		cancel()
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		// the library fills in its defaults, the key keeps the parameters requested by the devices
+		requested := params
+		sub, err := ps.client.Subscribe(context.Background(), &requested, shared.notifyCh)
+		if err != nil {
+			return nil, err
+		}
+		if sub != nil {
+			shared.id = sub.SubscriptionID
+			s.sdk.LoggingClient().Infof("[%s] subscription %d created: publishing interval %s, lifetime count %d, max keep-alive count %d",
+				s.deviceName, sub.SubscriptionID, sub.RevisedPublishingInterval, sub.RevisedLifetimeCount, sub.RevisedMaxKeepAliveCount)
+		}
+		shared.sub = sub
+	}
+
+	ps.subscriptions[params] = shared
+	shared.startDispatch()
+	if shared.client != nil {
+		ps.startPublishing()
+	}
+	return shared, nil
+}
+
+// unsubscribe removes the monitored items of the server from the subscription,
+// and deletes the subscription when no server uses it anymore
+func (ps *pooledSession) unsubscribe(s *Server, shared *sharedSubscription) {
+	ps.mu.Lock()
+	defer ps.mu.Unlock()
+
+	// a subscription lost with the connection is kept for recovery, with the monitored items of the server
+	if ps.subscriptions[shared.params] != shared || ps.client == nil || ps.client.State() == opcua.Closed {
+		return
+	}
+
+	shared.mu.Lock()
+	shared.refs--
+	last := shared.refs <= 0
+	shared.mu.Unlock()
+	ids := shared.remove(s)
+
+	ctx, cancel := context.WithTimeout(context.Background(), s.requestTimeout())
+	defer cancel()
+	if last {
+		shared.stop(ctx)
+		delete(ps.subscriptions, shared.params)
+		return
+	}
+	if err := shared.unmonitor(ctx, ids...); err != nil {
+		s.sdk.LoggingClient().Debugf("[%s] failed to delete monitored items: %v", s.deviceName, err)
+	}
+}
+
+// remove forgets the monitored items and client handles of the server, and returns the ids of its items
+func (shared *sharedSubscription) remove(s *Server) []uint32 {
+	shared.mu.Lock()
+	defer shared.mu.Unlock()
+
+	ids := shared.items[s]
+	delete(shared.items, s)
+	for handle, owner := range shared.handles {
+		if owner == s {
+			delete(shared.handles, handle)
+		}
+	}
+	return ids
+}
+
+// monitors reports whether the subscription holds monitored items of the server,
+// which is only the case when the subscription was recovered
+func (shared *sharedSubscription) monitors(s *Server) bool {
+	shared.mu.Lock()
+	defer shared.mu.Unlock()
+	return len(shared.items[s]) > 0
+}
+
+// register allocates a client handle, unique in the subscription, for a monitored item of the server
+func (shared *sharedSubscription) register(s *Server) uint32 {
+	shared.mu.Lock()
+	defer shared.mu.Unlock()
+
+	shared.next++
+	shared.handles[shared.next] = s
+	return shared.next
+}
+
+// Monitor creates monitored items on behalf of the server
+func (shared *sharedSubscription) Monitor(ctx context.Context, s *Server, ts ua.TimestampsToReturn,
+	items ...*ua.MonitoredItemCreateRequest) (*ua.CreateMonitoredItemsResponse, error) {
+	var res *ua.CreateMonitoredItemsResponse
+	var err error
+	if shared.client != nil {
+		res, err = shared.createMonitoredItems(ctx, ts, items)
+	} else {
+		res, err = shared.sub.Monitor(ctx, ts, items...)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	shared.mu.Lock()
+	defer shared.mu.Unlock()
+	for _, result := range res.Results {
+		if result.StatusCode == ua.StatusOK {
+			shared.items[s] = append(shared.items[s], result.MonitoredItemID)
+		}
+	}
+	return res, nil
+}
+
+// startDispatch starts delivering the notifications of the subscription, until it is stopped or lost
+func (shared *sharedSubscription) startDispatch() {
+	ctx, cancel := context.WithCancel(context.Background())
+	shared.cancel = cancel
+	shared.done = ctx.Done()
+	go shared.dispatch(ctx)
+}
+
+// dispatch delivers the notifications of the subscription to the servers owning the monitored items
+func (shared *sharedSubscription) dispatch(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case res := <-shared.notifyCh:
+			if res.Error != nil {
+				shared.lc.Debug(res.Error.Error())
+				continue
+			}
+			shared.deliver(res.Value)
+		}
+	}
+}
+
+// deliver hands the data changes and events of a notification to the servers owning the monitored items,
+// and returns the number of notifications delivered
+func (shared *sharedSubscription) deliver(notification any) int {
+	delivered := 0
+	switch notification := notification.(type) {
+	case *ua.DataChangeNotification:
+		for s, items := range shared.route(notification.MonitoredItems) {
+			if s.removed() {
+				continue
+			}
+			s.handleDataChange(&ua.DataChangeNotification{MonitoredItems: items})
+			delivered += len(items)
+		}
+	case *ua.EventNotificationList:
+		for s, events := range shared.routeEvents(notification.Events) {
+			if s.removed() {
+				continue
+			}
+			s.handleEvents(events)
+			delivered += len(events)
+		}
+	}
+	return delivered
+}
+
+func (shared *sharedSubscription) route(items []*ua.MonitoredItemNotification) map[*Server][]*ua.MonitoredItemNotification {
+	shared.mu.Lock()
+	defer shared.mu.Unlock()
+
+	routed := make(map[*Server][]*ua.MonitoredItemNotification)
+	for _, item := range items {
+		if s, ok := shared.handles[item.ClientHandle]; ok {
+			routed[s] = append(routed[s], item)
+		}
+	}
+	return routed
+}
+
+func (shared *sharedSubscription) routeEvents(events []*ua.EventFieldList) map[*Server][]*ua.EventFieldList {
+	shared.mu.Lock()
+	defer shared.mu.Unlock()
+
+	routed := make(map[*Server][]*ua.EventFieldList)
+	for _, event := range events {
+		if s, ok := shared.handles[event.ClientHandle]; ok {
+			routed[s] = append(routed[s], event)
+		}
+	}
+	return routed
+}
+
+// stop stops dispatching the notifications of the subscription and deletes it from the server
+func (shared *sharedSubscription) stop(ctx context.Context) {
+	shared.cancel()
+	if shared.client != nil {
+		req := &ua.DeleteSubscriptionsRequest{SubscriptionIDs: []uint32{shared.id}}
+		_ = shared.client.Send(ctx, req, func(ua.Response) error { return nil })
+		return
+	}
+	if shared.sub != nil {
+		_ = shared.sub.Cancel(ctx)
+	}
+}
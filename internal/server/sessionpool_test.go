@@ -0,0 +1,209 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2025 Schneider Electric
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package server
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/edgexfoundry/device-opcua-go/internal/test"
+	"github.com/edgexfoundry/device-opcua-go/pkg/gopcua"
+	gopcuaMocks "github.com/edgexfoundry/device-opcua-go/pkg/gopcua/mocks"
+	"github.com/gopcua/opcua"
+	"github.com/gopcua/opcua/ua"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// newSessionPool replaces the shared session pool for the duration of the test
+func newSessionPool(t *testing.T) {
+	orig := sessions
+	sessions = &sessionPool{sessions: make(map[string]*pooledSession)}
+	t.Cleanup(func() { sessions = orig })
+}
+
+func TestSessionPool_acquire(t *testing.T) {
+	newSessionPool(t)
+	clientMock := gopcuaMocks.NewMockClient(t)
+	dials := 0
+	origNewClient := gopcua.NewClient
+	t.Cleanup(func() { gopcua.NewClient = origNewClient })
+	gopcua.NewClient = func(endpoint string, opts ...opcua.Option) (gopcua.Client, error) {
+		dials++
+		return clientMock, nil
+	}
+	clientMock.On("Connect", mock.Anything).Return(nil).Once()
+	clientMock.On("State").Return(opcua.Connected)
+
+	conn := &connection{key: "shared", endpointURL: test.Address}
+	first := NewServer("First", test.NewDSMock(t), nil)
+	second := NewServer("Second", test.NewDSMock(t), nil)
+
+	ps1, owner1, err := sessions.acquire(first, conn)
+	require.NoError(t, err)
+	ps2, owner2, err := sessions.acquire(second, conn)
+	require.NoError(t, err)
+
+	assert.Same(t, ps1, ps2)
+	assert.True(t, owner1)
+	assert.False(t, owner2)
+	assert.Equal(t, 1, dials)
+	assert.Len(t, ps1.users, 2)
+
+	// the session stays open while a device uses it
+	require.NoError(t, sessions.release(ps1, first, defaultRequestTimeout))
+	assert.Len(t, sessions.sessions, 1)
+	assert.Same(t, second, ps1.owner)
+
+	clientMock.On("Close", mock.Anything).Return(nil).Once()
+	require.NoError(t, sessions.release(ps1, second, defaultRequestTimeout))
+	assert.Empty(t, sessions.sessions)
+}
+
+func TestServer_releaseSession(t *testing.T) {
+	newSessionPool(t)
+	clientMock := gopcuaMocks.NewMockClient(t)
+	origNewClient := gopcua.NewClient
+	t.Cleanup(func() { gopcua.NewClient = origNewClient })
+	gopcua.NewClient = func(endpoint string, opts ...opcua.Option) (gopcua.Client, error) {
+		return clientMock, nil
+	}
+	clientMock.On("Connect", mock.Anything).Return(nil).Once()
+	clientMock.On("State").Return(opcua.Connected)
+
+	s := NewServer("Test", test.NewDSMock(t), nil)
+	ps, _, err := sessions.acquire(s, &connection{key: "released", endpointURL: test.Address})
+	require.NoError(t, err)
+	s.session = ps
+	s.client = &Client{ps.client, context.Background()}
+
+	// the client is closed with a deadline, once the server is unlocked
+	clientMock.On("Close", test.RequestContext()).Return(nil).Once().Run(func(mock.Arguments) {
+		if !s.mu.TryLock() {
+			t.Error("client closed with s.mu held")
+			return
+		}
+		s.mu.Unlock()
+	})
+
+	s.mu.Lock()
+	release := s.releaseSession()
+	assert.Nil(t, s.client)
+	assert.Nil(t, s.session)
+	s.mu.Unlock()
+	clientMock.AssertNotCalled(t, "Close", mock.Anything)
+
+	release()
+	assert.Empty(t, sessions.sessions)
+	assert.Nil(t, ps.client)
+}
+
+func TestSessionPool_acquireError(t *testing.T) {
+	newSessionPool(t)
+	clientMock := gopcuaMocks.NewMockClient(t)
+	origNewClient := gopcua.NewClient
+	t.Cleanup(func() { gopcua.NewClient = origNewClient })
+	gopcua.NewClient = func(endpoint string, opts ...opcua.Option) (gopcua.Client, error) {
+		return clientMock, nil
+	}
+	clientMock.On("Connect", mock.Anything).Return(fmt.Errorf("error"))
+
+	_, _, err := sessions.acquire(NewServer("Test", test.NewDSMock(t), nil), &connection{key: "failing", endpointURL: test.Address})
+	assert.Error(t, err)
+	assert.Empty(t, sessions.sessions)
+}
+
+func TestSessionPool_acquireClosed(t *testing.T) {
+	newSessionPool(t)
+	closed, redialed := gopcuaMocks.NewMockClient(t), gopcuaMocks.NewMockClient(t)
+	origNewClient := gopcua.NewClient
+	t.Cleanup(func() { gopcua.NewClient = origNewClient })
+	gopcua.NewClient = func(endpoint string, opts ...opcua.Option) (gopcua.Client, error) {
+		return redialed, nil
+	}
+	closed.On("State").Return(opcua.Closed)
+	redialed.On("Connect", mock.Anything).Return(nil).Once()
+
+	// the subscriptions of a lost connection are kept, so that they are recovered
+	lost := &sharedSubscription{params: opcua.SubscriptionParameters{Interval: time.Second}, cancel: func() {}}
+	ps := &pooledSession{
+		key:           "lost",
+		client:        closed,
+		users:         make(map[*Server]bool),
+		subscriptions: map[opcua.SubscriptionParameters]*sharedSubscription{{Interval: time.Second}: lost},
+	}
+	sessions.sessions[ps.key] = ps
+
+	s := NewServer("Test", test.NewDSMock(t), nil)
+	got, owner, err := sessions.acquire(s, &connection{key: "lost", endpointURL: test.Address})
+	require.NoError(t, err)
+	assert.Same(t, ps, got)
+	assert.True(t, owner)
+	assert.Same(t, redialed, got.client)
+	assert.Empty(t, got.subscriptions)
+	assert.Same(t, lost, got.lost[opcua.SubscriptionParameters{Interval: time.Second}])
+
+	// unsubscribing from the lost subscription leaves the session alone
+	got.subscriptions[opcua.SubscriptionParameters{Interval: time.Second}] = &sharedSubscription{params: opcua.SubscriptionParameters{Interval: time.Second}, refs: 1}
+	got.unsubscribe(s, lost)
+	assert.Len(t, got.subscriptions, 1)
+}
+
+func TestServer_sessionKey(t *testing.T) {
+	ep := &ua.EndpointDescription{EndpointURL: test.Address, SecurityPolicyURI: ua.SecurityPolicyURINone, SecurityMode: ua.MessageSecurityModeNone}
+
+	s := NewServer("Test", test.NewDSMock(t), nil)
+	s.config = &Config{AuthMode: AuthModeUserName, SecretName: "operator"}
+	key := s.sessionKey(ep, "")
+
+	s.config = &Config{AuthMode: AuthModeUserName, SecretName: "operator"}
+	assert.Equal(t, key, s.sessionKey(ep, ""), "same parameters share the session")
+
+	s.config = &Config{AuthMode: AuthModeUserName, SecretName: "engineer"}
+	assert.NotEqual(t, key, s.sessionKey(ep, ""), "another identity needs its own session")
+
+	s.config = &Config{AuthMode: AuthModeUserName, SecretName: "operator"}
+	assert.NotEqual(t, key, s.sessionKey(ep, "thumbprint"), "another certificate needs its own session")
+}
+
+func TestPooledSession_subscribe(t *testing.T) {
+	clientMock := gopcuaMocks.NewMockClient(t)
+	clientMock.On("Subscribe", mock.Anything, mock.Anything, mock.Anything).Return(nil, nil).Twice()
+	clientMock.On("State").Return(opcua.Connected)
+
+	ps := &pooledSession{client: clientMock, subscriptions: make(map[opcua.SubscriptionParameters]*sharedSubscription)}
+	first := NewServer("First", test.NewDSMock(t), nil)
+	second := NewServer("Second", test.NewDSMock(t), nil)
+
+	sub1, err := ps.subscribe(first, opcua.SubscriptionParameters{Interval: time.Second})
+	require.NoError(t, err)
+	sub2, err := ps.subscribe(second, opcua.SubscriptionParameters{Interval: time.Second})
+	require.NoError(t, err)
+	assert.Same(t, sub1, sub2, "one subscription per set of parameters")
+	slow, err := ps.subscribe(second, opcua.SubscriptionParameters{Interval: time.Minute, Priority: 1})
+	require.NoError(t, err)
+	assert.NotSame(t, sub1, slow)
+	slow.cancel()
+	delete(ps.subscriptions, slow.params)
+
+	// client handles are unique in the subscription, and notifications are routed to their device
+	h1, h2 := sub1.register(first), sub1.register(second)
+	assert.NotEqual(t, h1, h2)
+	routed := sub1.route([]*ua.MonitoredItemNotification{{ClientHandle: h1}, {ClientHandle: h2}, {ClientHandle: h2}, {ClientHandle: 999}})
+	assert.Len(t, routed[first], 1)
+	assert.Len(t, routed[second], 2)
+
+	ps.unsubscribe(first, sub1)
+	assert.Len(t, ps.subscriptions, 1)
+	assert.Len(t, sub1.route([]*ua.MonitoredItemNotification{{ClientHandle: h1}}), 0)
+
+	ps.unsubscribe(second, sub2)
+	assert.Empty(t, ps.subscriptions)
+}
@@ -9,7 +9,9 @@
 package server
 
 import (
+	"context"
 	"fmt"
+	"slices"
 	"time"
 
 	"github.com/edgexfoundry/device-opcua-go/pkg/result"
@@ -21,104 +23,203 @@ import (
 // StartSubscriptionListener initializes a new OPCUA client and subscribes to the resources
 // specified by the user in the device protocol configuration
 func (s *Server) StartSubscriptionListener() error {
+	s.mu.Lock()
+	ctx := s.context.ctx
+	s.mu.Unlock()
+
+	return s.runSubscriptionListener(ctx, func() {})
+}
+
+// runSubscriptionListener connects and listens until ctx is cancelled or the connection
+// is lost, calling subscribed each time the monitored items are created
+func (s *Server) runSubscriptionListener(ctx context.Context, subscribed func()) error {
 	if err := s.Connect(); err != nil {
 		return err
 	}
 	// Connection will be explicitely closed by s.Cleanup, which is called by the Device Service
 	// when the device is removed or updated. Otherwise it will be closed when the service stops
 
-	notifyCh := make(chan *opcua.PublishNotificationData)
+	for {
+		failover, err := s.listen(ctx, subscribed)
+		if err != nil || !failover {
+			return err
+		}
+
+		// the subscription is created again on the next healthy endpoint
+		s.closeClient()
+		if err := s.connect(true); err != nil {
+			s.sdk.LoggingClient().Errorf("[%s] failover failed: %v", s.deviceName, err)
+			return err
+		}
+	}
+}
 
-	sub, err := s.client.Subscribe(s.client.ctx,
-		&opcua.SubscriptionParameters{
-			Interval: time.Duration(500) * time.Millisecond,
-		}, notifyCh)
+// listen subscribes to the resources and reads notifications until ctx is cancelled, until
+// the connection is lost, or until the active endpoint is unhealthy and another one should be used
+func (s *Server) listen(ctx context.Context, subscribed func()) (bool, error) {
+	// devices sharing the session share the subscription, whose notifications
+	// are dispatched to handleDataChange of the device owning the monitored item
+	session := s.session
+	sub, err := session.subscribe(s, s.config.SubscriptionParameters())
 	if err != nil {
-		return err
+		return false, err
 	}
-	defer sub.Cancel(s.client.ctx) //nolint:errcheck
+	defer session.unsubscribe(s, sub)
 
-	if err := s.configureMonitoredItems(sub); err != nil {
-		return err
+	// a recovered subscription keeps the monitored items of the server, unless its resources changed
+	if !sub.monitors(s) || !s.monitorsResources() {
+		if err := sub.unmonitor(context.Background(), sub.remove(s)...); err != nil {
+			s.sdk.LoggingClient().Debugf("[%s] failed to delete monitored items: %v", s.deviceName, err)
+		}
+		if err := s.configureMonitoredItems(sub); err != nil {
+			return false, err
+		}
 	}
+	// the alarms raised or cleared while the subscription was lost are only known after a refresh
+	s.refreshConditions(sub)
+	subscribed()
 
-	// read from subscription's notification channel until ctx is cancelled
+	// the subscription is lost with the connection, or when a handler connected a new client
+	s.mu.Lock()
+	subscribedClient := s.client
+	s.mu.Unlock()
+	ticker := time.NewTicker(failoverCheckInterval)
+	defer ticker.Stop()
+	failover := len(s.config.EndpointURLs()) > 1
+
+	// wait until ctx is cancelled
 	for {
 		select {
 		// context return
-		case <-s.context.ctx.Done():
-			return nil
-		// receive Publish Notification Data
-		case res := <-notifyCh:
-			if res.Error != nil {
-				s.sdk.LoggingClient().Debug(res.Error.Error())
-				continue
+		case <-ctx.Done():
+			return false, nil
+		case <-ticker.C:
+			s.mu.Lock()
+			client := s.client
+			s.mu.Unlock()
+			if client == nil {
+				// closed by Cleanup
+				return false, nil
+			}
+			if client != subscribedClient || client.State() == opcua.Closed {
+				return false, fmt.Errorf("[%s] connection to endpoint %s lost", s.deviceName, s.active)
 			}
-			switch dataChangeNotification := res.Value.(type) {
-			// result type: DateChange StatusChange
-			case *ua.DataChangeNotification:
-				s.handleDataChange(dataChangeNotification)
+			// the health of the active endpoint only matters when there is another one to fail over to
+			if failover && !s.endpointHealthy(client) {
+				s.sdk.LoggingClient().Warnf("[%s] endpoint %s is unhealthy, failing over", s.deviceName, s.active)
+				return true, nil
 			}
 		}
 	}
 }
 
-func (s *Server) configureMonitoredItems(sub *opcua.Subscription) error {
+func (s *Server) configureMonitoredItems(sub *sharedSubscription) error {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	var i uint32
+	s.resourceMap = make(map[uint32]string)
+	s.events = make(map[uint32]*eventItem)
 	for _, resource := range s.config.Resources {
 		deviceResource, ok := s.sdk.DeviceResource(s.deviceName, resource)
 		if !ok {
 			s.sdk.LoggingClient().Warnf("[%s] unable to find resource with name %s", s.deviceName, resource)
-			i++
 			continue
 		}
 
-		id, err := getNodeID(deviceResource.Attributes, NODE)
+		// resources with an event notifier receive its events instead of the value of a node
+		_, events := deviceResource.Attributes[EVENTNOTIFIER]
+		attr := NODE
+		if events {
+			attr = EVENTNOTIFIER
+		}
+		id, err := getNodeID(deviceResource.Attributes, attr)
 		if err != nil {
 			return err
 		}
 
-		// arbitrary client handle for the monitoring item
-		handle := i + 42
+		// client handle for the monitoring item, unique in the subscription shared by devices
+		handle := sub.register(s)
 		// map the client handle so we know what the value returned represents
 		s.resourceMap[handle] = resource
-		miCreateRequest := opcua.NewMonitoredItemCreateRequestWithDefaults(id, ua.AttributeIDValue, handle)
-		res, err := sub.Monitor(s.client.ctx, ua.TimestampsToReturnBoth, miCreateRequest)
-		if err != nil || res.Results[0].StatusCode != ua.StatusOK {
+
+		var miCreateRequest *ua.MonitoredItemCreateRequest
+		var item *eventItem
+		if events {
+			miCreateRequest, item, err = eventItemRequest(id, handle, deviceResource.Attributes)
+		} else {
+			miCreateRequest, err = monitoredItemRequest(id, handle, deviceResource.Attributes)
+		}
+		if err != nil {
+			return fmt.Errorf("[%s] resource %s: %v", s.deviceName, resource, err)
+		}
+		if _, err := timestampClock(deviceResource.Attributes, s.deviceTimestampClock()); err != nil {
+			return fmt.Errorf("[%s] resource %s: %v", s.deviceName, resource, err)
+		}
+		if s.client == nil {
+			return fmt.Errorf("[%s] connection closed", s.deviceName)
+		}
+		res, err := sub.Monitor(s.client.ctx, s, ua.TimestampsToReturnBoth, miCreateRequest)
+		if err != nil {
 			return err
 		}
+		if res.Results[0].StatusCode != ua.StatusOK {
+			s.sdk.LoggingClient().Warnf("[%s] unable to monitor %s: %v", s.deviceName, resource, res.Results[0].StatusCode)
+			continue
+		}
+		s.logRevisedParameters(resource, deviceResource.Attributes, miCreateRequest.RequestedParameters, res.Results[0])
+		if events {
+			item.monitoredItemID = res.Results[0].MonitoredItemID
+			s.events[handle] = item
+			s.logEventFilterResult(resource, item.fields, res.Results[0])
+		}
 
 		s.sdk.LoggingClient().Infof("[%s] start incoming data listening for %s", s.deviceName, resource)
-		i++
 	}
 
 	return nil
 }
 
+// monitorsResources reports whether the client handles of the server map the resources it is configured with
+func (s *Server) monitorsResources() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	mapped := make(map[string]bool, len(s.resourceMap))
+	for _, resource := range s.resourceMap {
+		mapped[resource] = true
+	}
+	return len(mapped) == len(s.config.Resources) && !slices.ContainsFunc(s.config.Resources, func(r string) bool { return !mapped[r] })
+}
+
 func (s *Server) handleDataChange(dcn *ua.DataChangeNotification) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
+	policy := s.qualityPolicy()
 	for _, item := range dcn.MonitoredItems {
 		var data any
 
+		resourceName := s.resourceMap[item.ClientHandle]
+		if !qualityAccepts(policy, item.Value.Status) {
+			s.sdk.LoggingClient().Debugf("[%s] value of %s dropped by quality policy %s: %v", s.deviceName, resourceName, policy, item.Value.Status)
+			continue
+		}
+
 		variant := item.Value.Value
 		if variant != nil {
 			data = variant.Value()
 		} else {
 			continue
 		}
-		resourceName := s.resourceMap[item.ClientHandle]
-		if err := s.onIncomingDataReceived(data, resourceName); err != nil {
+		if err := s.onIncomingDataReceived(data, resourceName, item.Value); err != nil {
 			s.sdk.LoggingClient().Errorf("%v", err)
 		}
 	}
 }
 
-func (s *Server) onIncomingDataReceived(data any, nodeResourceName string) error {
+// onIncomingDataReceived sends the reading of the resource, whose Origin is set by the timestamps
+// of the value when given, or else by the local clock
+func (s *Server) onIncomingDataReceived(data any, nodeResourceName string, value *ua.DataValue) error {
 	deviceResource, ok := s.sdk.DeviceResource(s.deviceName, nodeResourceName)
 	if !ok {
 		return fmt.Errorf("[%s] Incoming reading ignored. No DeviceObject found: deviceResource=%v value=%v", s.deviceName, nodeResourceName, data)
@@ -134,6 +235,12 @@ func (s *Server) onIncomingDataReceived(data any, nodeResourceName string) error
 	if err != nil {
 		return fmt.Errorf("[%s] Incoming reading ignored. deviceResource=%v value=%v", s.deviceName, nodeResourceName, data)
 	}
+	// an unknown timestamp attribute is reported when the monitored item is created
+	clock, _ := timestampClock(deviceResource.Attributes, s.deviceTimestampClock())
+	setTimestamps(result, value, clock)
+	if value != nil {
+		setQuality(result, value.Status)
+	}
 
 	asyncValues := &sdkModels.AsyncValues{
 		DeviceName:    s.deviceName,
@@ -8,12 +8,15 @@ package server
 
 import (
 	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/edgexfoundry/device-opcua-go/internal/test"
 	"github.com/edgexfoundry/device-opcua-go/pkg/gopcua"
 	"github.com/edgexfoundry/go-mod-core-contracts/v4/models"
 	"github.com/gopcua/opcua/ua"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func Test_StartSubscriptionListener(t *testing.T) {
@@ -21,7 +24,7 @@ func Test_StartSubscriptionListener(t *testing.T) {
 		dsMock := test.NewDSMock(t)
 		dsMock.On("GetDeviceByName", "Test").Return(models.Device{}, fmt.Errorf("error"))
 
-		s := NewServer("Test", dsMock)
+		s := NewServer("Test", dsMock, nil)
 		err := s.StartSubscriptionListener()
 		if err == nil {
 			t.Error("expected err to exist in test environment")
@@ -36,7 +39,7 @@ func Test_configureMonitoredItems(t *testing.T) {
 		dsMock.On("DeviceResource", "Test", "b").Return(models.DeviceResource{}, false)
 		dsMock.On("DeviceResource", "Test", "c").Return(models.DeviceResource{}, false)
 
-		s := NewServer("Test", dsMock)
+		s := NewServer("Test", dsMock, nil)
 		s.config = &Config{Resources: []string{"a", "b", "c"}}
 		err := s.configureMonitoredItems(nil)
 		if err != nil {
@@ -50,8 +53,8 @@ func Test_onIncomingDataReceived(t *testing.T) {
 		dsMock := test.NewDSMock(t)
 		dsMock.On("DeviceResource", "Test", "TestResource").Return(models.DeviceResource{}, false)
 
-		s := NewServer("Test", dsMock)
-		err := s.onIncomingDataReceived("42", "TestResource")
+		s := NewServer("Test", dsMock, nil)
+		err := s.onIncomingDataReceived("42", "TestResource", nil)
 		if err == nil {
 			t.Error("expected err to exist in test environment")
 		}
@@ -86,10 +89,10 @@ func TestDriver_initClient(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			dsMock := test.NewDSMock(t)
-			s := NewServer("Test", dsMock)
+			s := NewServer("Test", dsMock, nil)
 
 			s.config = tt.config
-			err := s.initClient()
+			_, err := s.initClient(tt.config.Endpoint, tt.config.Endpoint)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("Driver.getClient() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -98,17 +101,34 @@ func TestDriver_initClient(t *testing.T) {
 	}
 }
 
+func TestServer_initClientReverse(t *testing.T) {
+	origGetEndpoints := gopcua.GetEndpoints
+	defer func() { gopcua.GetEndpoints = origGetEndpoints }()
+	test.MockGetEndpoints()
+
+	const endpoint = "opc.tcp://plc.example:4840"
+	s := NewServer("Test", test.NewDSMock(t), nil)
+	s.config = &Config{Endpoint: endpoint, Policy: "None", Mode: "None", ReverseConnect: true}
+
+	// the client dials the bridge, the session is keyed by the configured endpoint
+	conn, err := s.initClient(endpoint, test.Address)
+	require.NoError(t, err)
+	assert.Equal(t, test.Address, conn.endpointURL)
+	assert.Equal(t, endpoint, s.endpoint.EndpointURL)
+	assert.True(t, strings.HasPrefix(conn.key, endpoint+"|"))
+}
+
 func TestDriver_handleDataChange(t *testing.T) {
 	t.Run("OK - no monitored items", func(t *testing.T) {
 		dsMock := test.NewDSMock(t)
-		s := NewServer("Test", dsMock)
+		s := NewServer("Test", dsMock, nil)
 		s.handleDataChange(&ua.DataChangeNotification{MonitoredItems: make([]*ua.MonitoredItemNotification, 0)})
 	})
 
 	t.Run("OK - call onIncomingDataReceived", func(t *testing.T) {
 		dsMock := test.NewDSMock(t)
 		dsMock.On("DeviceResource", "Test", "").Return(models.DeviceResource{Name: "TestResource"}, true)
-		s := NewServer("Test", dsMock)
+		s := NewServer("Test", dsMock, nil)
 
 		s.handleDataChange(&ua.DataChangeNotification{
 			MonitoredItems: []*ua.MonitoredItemNotification{
@@ -116,4 +136,17 @@ func TestDriver_handleDataChange(t *testing.T) {
 			},
 		})
 	})
+
+	t.Run("OK - bad value dropped by quality policy", func(t *testing.T) {
+		dsMock := test.NewDSMock(t)
+		s := NewServer("Test", dsMock, nil)
+		s.config = &Config{Quality: QualityDropBad}
+
+		// DeviceResource is not expected, the value is dropped before the reading is built
+		s.handleDataChange(&ua.DataChangeNotification{
+			MonitoredItems: []*ua.MonitoredItemNotification{
+				{ClientHandle: 1, Value: &ua.DataValue{Value: ua.MustVariant("42"), Status: ua.StatusBadSensorFailure}},
+			},
+		})
+	})
 }
@@ -0,0 +1,72 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2025 Schneider Electric
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package server
+
+import (
+	"context"
+	"math/rand/v2"
+	"time"
+)
+
+const (
+	// reconnectMinDelay and reconnectMaxDelay bound the delay before the subscription listener
+	// is started again, which doubles after every failed attempt
+	reconnectMinDelay = time.Second
+	reconnectMaxDelay = 2 * time.Minute
+)
+
+// backoff computes exponentially growing delays with jitter, so that devices losing
+// the same server do not reconnect all at once
+type backoff struct {
+	min, max time.Duration
+	attempt  int
+}
+
+// next returns the delay before the next attempt, between half and all of the current backoff
+func (b *backoff) next() time.Duration {
+	delay := b.max
+	if b.attempt < 32 {
+		delay = min(b.min<<b.attempt, b.max)
+	}
+	b.attempt++
+	return delay/2 + rand.N(delay/2+1)
+}
+
+func (b *backoff) reset() {
+	b.attempt = 0
+}
+
+// superviseSubscription runs the subscription listener until ctx is cancelled, starting
+// it again with backoff when the server is unavailable or the connection is lost
+func (s *Server) superviseSubscription(ctx context.Context) {
+	retry := &backoff{min: reconnectMinDelay, max: reconnectMaxDelay}
+	for {
+		err := s.runSubscriptionListener(ctx, retry.reset)
+		if ctx.Err() != nil {
+			return
+		}
+
+		delay := retry.next()
+		s.sdk.LoggingClient().Warnf("[%s] subscription listener stopped: %v, retrying in %s", s.deviceName, err, delay.Round(time.Millisecond))
+
+		// the next attempt connects a new client, the monitored items are created again on it.
+		// Cleanup cancels ctx with s.mu held, the client of a restarted server is left alone
+		s.mu.Lock()
+		release := func() {}
+		if ctx.Err() == nil {
+			release = s.releaseSession()
+		}
+		s.mu.Unlock()
+		release()
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(delay):
+		}
+	}
+}
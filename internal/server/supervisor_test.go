@@ -0,0 +1,82 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2025 Schneider Electric
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package server
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/edgexfoundry/device-opcua-go/internal/test"
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestBackoff_next(t *testing.T) {
+	b := &backoff{min: time.Second, max: 10 * time.Second}
+	want := []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 8 * time.Second, 10 * time.Second, 10 * time.Second}
+	for i, ceiling := range want {
+		delay := b.next()
+		assert.GreaterOrEqual(t, delay, ceiling/2, "attempt %d", i)
+		assert.LessOrEqual(t, delay, ceiling, "attempt %d", i)
+	}
+
+	b.attempt = 100
+	assert.LessOrEqual(t, b.next(), 10*time.Second, "no overflow after many attempts")
+
+	b.reset()
+	assert.LessOrEqual(t, b.next(), time.Second)
+}
+
+func TestServer_superviseSubscription(t *testing.T) {
+	t.Run("stops when the context is cancelled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		attempted := make(chan struct{})
+
+		dsMock := test.NewDSMock(t)
+		dsMock.On("GetDeviceByName", "Test").Return(models.Device{}, fmt.Errorf("error")).
+			Run(func(_ mock.Arguments) { close(attempted) }).Once()
+
+		s := NewServer("Test", dsMock, nil)
+		done := make(chan struct{})
+		go func() {
+			s.superviseSubscription(ctx)
+			close(done)
+		}()
+
+		<-attempted
+		cancel()
+		select {
+		case <-done:
+		case <-time.After(5 * time.Second):
+			t.Fatal("supervisor did not stop")
+		}
+	})
+
+	t.Run("retries until the server is available", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		retried := make(chan struct{})
+
+		dsMock := test.NewDSMock(t)
+		dsMock.On("GetDeviceByName", "Test").Return(models.Device{}, fmt.Errorf("error")).Once()
+		dsMock.On("GetDeviceByName", "Test").Return(models.Device{}, fmt.Errorf("error")).
+			Run(func(_ mock.Arguments) { close(retried) }).Once()
+
+		s := NewServer("Test", dsMock, nil)
+		go s.superviseSubscription(ctx)
+
+		select {
+		case <-retried:
+		case <-time.After(5 * time.Second):
+			t.Fatal("listener not started again")
+		}
+		cancel()
+	})
+}
@@ -0,0 +1,106 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2025 Schneider Electric
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package server
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	sdkModel "github.com/edgexfoundry/device-sdk-go/v4/pkg/models"
+	"github.com/gopcua/opcua/ua"
+	"github.com/spf13/cast"
+)
+
+// Clocks which can set the Origin of the readings, in order of fallback when a value lacks a timestamp
+const (
+	TimestampSource = "Source"
+	TimestampServer = "Server"
+	TimestampLocal  = "Local"
+)
+
+// Tags holding the timestamps of a value which did not set the Origin of its reading
+const (
+	TagSourceTimestamp = "SourceTimestamp"
+	TagServerTimestamp = "ServerTimestamp"
+	TagLocalTimestamp  = "LocalTimestamp"
+)
+
+var timestampClocks = []string{TimestampSource, TimestampServer, TimestampLocal}
+
+// timestampClock returns the clock setting the Origin of the readings of the resource, given by
+// its timestamp attribute or else by the Timestamp of the device. An unknown attribute is
+// reported with the clock of the device.
+func timestampClock(attrs map[string]any, device string) (string, error) {
+	if device == "" {
+		device = TimestampLocal
+	}
+	v, ok := attrs[TIMESTAMP]
+	if !ok {
+		return device, nil
+	}
+
+	name := cast.ToString(v)
+	for _, clock := range timestampClocks {
+		if strings.EqualFold(name, clock) {
+			return clock, nil
+		}
+	}
+	return device, fmt.Errorf("unknown %s %v", TIMESTAMP, v)
+}
+
+// setTimestamps sets the Origin of the reading to the timestamp of the value given by the clock,
+// or by the next clock when the server did not return it, and tags the reading with the others.
+// The local timestamp is the Origin set when the reading was created.
+func setTimestamps(cv *sdkModel.CommandValue, value *ua.DataValue, clock string) {
+	if cv == nil || value == nil {
+		return
+	}
+	timestamps := map[string]time.Time{
+		TimestampSource: value.SourceTimestamp,
+		TimestampServer: value.ServerTimestamp,
+		TimestampLocal:  time.Unix(0, cv.Origin),
+	}
+	tags := map[string]string{
+		TimestampSource: TagSourceTimestamp,
+		TimestampServer: TagServerTimestamp,
+		TimestampLocal:  TagLocalTimestamp,
+	}
+
+	first := slices.Index(timestampClocks, clock)
+	if first < 0 {
+		first = len(timestampClocks) - 1
+	}
+	origin := ""
+	for _, c := range timestampClocks[first:] {
+		if !timestamps[c].IsZero() {
+			origin = c
+			break
+		}
+	}
+
+	if cv.Tags == nil {
+		cv.Tags = make(map[string]string)
+	}
+	for _, c := range timestampClocks {
+		switch {
+		case c == origin:
+			cv.Origin = timestamps[c].UnixNano()
+		case !timestamps[c].IsZero():
+			cv.Tags[tags[c]] = timestamps[c].UTC().Format(time.RFC3339Nano)
+		}
+	}
+}
+
+// deviceTimestampClock returns the clock of the device, local until its configuration is loaded
+func (s *Server) deviceTimestampClock() string {
+	if s.config == nil || s.config.Timestamp == "" {
+		return TimestampLocal
+	}
+	return s.config.Timestamp
+}
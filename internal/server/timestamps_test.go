@@ -0,0 +1,104 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2025 Schneider Electric
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package server
+
+import (
+	"testing"
+	"time"
+
+	sdkModel "github.com/edgexfoundry/device-sdk-go/v4/pkg/models"
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/common"
+	"github.com/gopcua/opcua/ua"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTimestampClock(t *testing.T) {
+	tests := []struct {
+		name    string
+		attrs   map[string]any
+		device  string
+		want    string
+		wantErr bool
+	}{
+		{name: "OK - local by default", want: TimestampLocal},
+		{name: "OK - device clock", device: TimestampServer, want: TimestampServer},
+		{name: "OK - resource overrides device", attrs: map[string]any{TIMESTAMP: "source"}, device: TimestampServer, want: TimestampSource},
+		{name: "NOK - unknown resource clock", attrs: map[string]any{TIMESTAMP: "Device"}, device: TimestampServer, want: TimestampServer, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := timestampClock(tt.attrs, tt.device)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestSetTimestamps(t *testing.T) {
+	local := time.Date(2025, 1, 1, 12, 0, 2, 0, time.UTC)
+	source := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	server := time.Date(2025, 1, 1, 12, 0, 1, 0, time.UTC)
+
+	tests := []struct {
+		name       string
+		value      *ua.DataValue
+		clock      string
+		wantOrigin time.Time
+		wantTags   map[string]string
+	}{
+		{
+			name:       "source",
+			value:      &ua.DataValue{SourceTimestamp: source, ServerTimestamp: server},
+			clock:      TimestampSource,
+			wantOrigin: source,
+			wantTags:   map[string]string{TagServerTimestamp: "2025-01-01T12:00:01Z", TagLocalTimestamp: "2025-01-01T12:00:02Z"},
+		},
+		{
+			name:       "server",
+			value:      &ua.DataValue{SourceTimestamp: source, ServerTimestamp: server},
+			clock:      TimestampServer,
+			wantOrigin: server,
+			wantTags:   map[string]string{TagSourceTimestamp: "2025-01-01T12:00:00Z", TagLocalTimestamp: "2025-01-01T12:00:02Z"},
+		},
+		{
+			name:       "local",
+			value:      &ua.DataValue{SourceTimestamp: source},
+			clock:      TimestampLocal,
+			wantOrigin: local,
+			wantTags:   map[string]string{TagSourceTimestamp: "2025-01-01T12:00:00Z"},
+		},
+		{
+			name:       "source missing falls back to server",
+			value:      &ua.DataValue{ServerTimestamp: server},
+			clock:      TimestampSource,
+			wantOrigin: server,
+			wantTags:   map[string]string{TagLocalTimestamp: "2025-01-01T12:00:02Z"},
+		},
+		{
+			name:       "no timestamps falls back to local",
+			value:      &ua.DataValue{},
+			clock:      TimestampSource,
+			wantOrigin: local,
+			wantTags:   map[string]string{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cv, err := sdkModel.NewCommandValueWithOrigin("Temperature", common.ValueTypeFloat64, 21.5, local.UnixNano())
+			require.NoError(t, err)
+
+			setTimestamps(cv, tt.value, tt.clock)
+			assert.Equal(t, tt.wantOrigin.UnixNano(), cv.Origin)
+			assert.Equal(t, tt.wantTags, cv.Tags)
+		})
+	}
+}
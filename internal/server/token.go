@@ -0,0 +1,114 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2025 Schneider Electric
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// tokenRefreshMargin is how long before expiry a cached token is considered stale
+	tokenRefreshMargin = 30 * time.Second
+	// defaultTokenLifetime is used when the identity provider does not return expires_in
+	defaultTokenLifetime = 5 * time.Minute
+	// minTokenRefreshWait bounds the refreshes of tokens living shorter than tokenRefreshMargin
+	minTokenRefreshWait = time.Second
+)
+
+// tokenResponse is the OAuth2 access token response, see RFC 6749 section 5.1
+type tokenResponse struct {
+	AccessToken string `json:"access_token"`
+	TokenType   string `json:"token_type"`
+	ExpiresIn   int64  `json:"expires_in"`
+}
+
+// tokenSource fetches access tokens from an OAuth2 token endpoint using the
+// client credentials grant and caches them until they are about to expire
+type tokenSource struct {
+	endpoint     string
+	clientID     string
+	clientSecret string
+	scope        string
+	httpClient   *http.Client
+
+	mu     sync.Mutex
+	token  []byte
+	expiry time.Time
+}
+
+func newTokenSource(endpoint, clientID, clientSecret, scope string) *tokenSource {
+	return &tokenSource{
+		endpoint:     endpoint,
+		clientID:     clientID,
+		clientSecret: clientSecret,
+		scope:        scope,
+		httpClient:   &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+// Token returns the cached token, or requests a new one if the cached token is missing or about to expire
+func (ts *tokenSource) Token(ctx context.Context) ([]byte, time.Time, error) {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+
+	if ts.token != nil && time.Now().Before(ts.expiry.Add(-tokenRefreshMargin)) {
+		return ts.token, ts.expiry, nil
+	}
+
+	form := url.Values{"grant_type": {"client_credentials"}}
+	if ts.scope != "" {
+		form.Set("scope", ts.scope)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, ts.endpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.SetBasicAuth(url.QueryEscape(ts.clientID), url.QueryEscape(ts.clientSecret))
+
+	resp, err := ts.httpClient.Do(req)
+	if err != nil {
+		return nil, time.Time{}, fmt.Errorf("token request failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, time.Time{}, fmt.Errorf("token request failed: %s", resp.Status)
+	}
+
+	var res tokenResponse
+	if err := json.NewDecoder(resp.Body).Decode(&res); err != nil {
+		return nil, time.Time{}, fmt.Errorf("invalid token response: %v", err)
+	}
+	if res.AccessToken == "" {
+		return nil, time.Time{}, fmt.Errorf("invalid token response: access_token missing")
+	}
+
+	lifetime := defaultTokenLifetime
+	if res.ExpiresIn > 0 {
+		lifetime = time.Duration(res.ExpiresIn) * time.Second
+	}
+
+	ts.token = []byte(res.AccessToken)
+	ts.expiry = time.Now().Add(lifetime)
+	return ts.token, ts.expiry, nil
+}
+
+// tokenRefreshWait returns how long to wait before refreshing a token expiring at expiry, the
+// margin before expiry but at least half its remaining lifetime and minTokenRefreshWait
+func tokenRefreshWait(expiry time.Time) time.Duration {
+	remaining := time.Until(expiry)
+	return max(remaining-tokenRefreshMargin, remaining/2, minTokenRefreshWait)
+}
@@ -0,0 +1,117 @@
+// -*- Mode: Go; indent-tabs-mode: t -*-
+//
+// Copyright (C) 2025 Schneider Electric
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package server
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// newTokenServer starts a stand-in identity provider returning tokens valid for expiresIn seconds
+func newTokenServer(t *testing.T, expiresIn int) (*httptest.Server, *atomic.Int32) {
+	var requests atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id, secret, ok := r.BasicAuth()
+		if !ok || id != "client" || secret != "secret" || r.FormValue("grant_type") != "client_credentials" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		n := requests.Add(1)
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"access_token":"token-%d","token_type":"Bearer","expires_in":%d}`, n, expiresIn)
+	}))
+	t.Cleanup(srv.Close)
+	return srv, &requests
+}
+
+func TestTokenSource_Token(t *testing.T) {
+	t.Run("OK - token is cached until expiry", func(t *testing.T) {
+		srv, requests := newTokenServer(t, 3600)
+		ts := newTokenSource(srv.URL, "client", "secret", "")
+
+		for i := 0; i < 3; i++ {
+			token, _, err := ts.Token(context.Background())
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if string(token) != "token-1" {
+				t.Errorf("Token() = %s, want token-1", token)
+			}
+		}
+		if requests.Load() != 1 {
+			t.Errorf("expected 1 token request, got %d", requests.Load())
+		}
+	})
+
+	t.Run("OK - token about to expire is renewed", func(t *testing.T) {
+		srv, requests := newTokenServer(t, 10)
+		ts := newTokenSource(srv.URL, "client", "secret", "")
+
+		for i := 0; i < 2; i++ {
+			if _, _, err := ts.Token(context.Background()); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		}
+		if requests.Load() != 2 {
+			t.Errorf("expected 2 token requests, got %d", requests.Load())
+		}
+	})
+
+	t.Run("NOK - invalid client credentials", func(t *testing.T) {
+		srv, _ := newTokenServer(t, 3600)
+		ts := newTokenSource(srv.URL, "client", "wrong", "")
+
+		if _, _, err := ts.Token(context.Background()); err == nil {
+			t.Error("expected an error but got none")
+		}
+	})
+
+	t.Run("NOK - response without access token", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprint(w, `{"token_type":"Bearer"}`)
+		}))
+		defer srv.Close()
+		ts := newTokenSource(srv.URL, "client", "secret", "")
+
+		if _, _, err := ts.Token(context.Background()); err == nil {
+			t.Error("expected an error but got none")
+		}
+	})
+}
+
+func TestTokenRefreshWait(t *testing.T) {
+	tests := []struct {
+		name      string
+		expiresIn int
+		wantMin   time.Duration
+		wantMax   time.Duration
+	}{
+		{name: "refreshed before the margin", expiresIn: 3600, wantMin: 3569 * time.Second, wantMax: 3570 * time.Second},
+		{name: "short lifetime refreshed at half", expiresIn: 30, wantMin: 14 * time.Second, wantMax: 15 * time.Second},
+		{name: "shorter lifetime refreshed at half", expiresIn: 10, wantMin: 4 * time.Second, wantMax: 5 * time.Second},
+		{name: "minimum wait", expiresIn: 1, wantMin: time.Second, wantMax: time.Second},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv, _ := newTokenServer(t, tt.expiresIn)
+			ts := newTokenSource(srv.URL, "client", "secret", "")
+
+			_, expiry, err := ts.Token(context.Background())
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if wait := tokenRefreshWait(expiry); wait < tt.wantMin || wait > tt.wantMax {
+				t.Errorf("tokenRefreshWait() = %v, want between %v and %v", wait, tt.wantMin, tt.wantMax)
+			}
+		})
+	}
+}
@@ -7,7 +7,11 @@
 package server
 
 import (
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
 	"fmt"
+	"os"
 
 	"github.com/gopcua/opcua/ua"
 )
@@ -17,6 +21,29 @@ const (
 	OBJECT   string = "objectId"
 	METHOD   string = "methodId"
 	INPUTMAP string = "inputMap"
+
+	// Optional attributes of a subscribed resource, tuning its monitored item
+	SAMPLINGINTERVAL string = "samplingInterval"
+	QUEUESIZE        string = "queueSize"
+	DISCARDOLDEST    string = "discardOldest"
+	DEADBANDTYPE     string = "deadbandType"
+	DEADBANDVALUE    string = "deadbandValue"
+
+	// Attribute of the node read for a resource, Value by default
+	ATTRIBUTEID string = "attributeId"
+
+	// Elements of an array value read and written for a resource, in the NumericRange syntax such as 17 or 0:9,2
+	INDEXRANGE string = "indexRange"
+
+	// Maximum age in milliseconds of the value returned to a read, overriding the ReadMaxAge of the device
+	MAXAGE string = "maxAge"
+
+	// Clock setting the Origin of the readings of a resource, overriding the Timestamp of the device
+	TIMESTAMP string = "timestamp"
+
+	// Attributes of a resource receiving the events of a notifier node as Object readings
+	EVENTNOTIFIER string = "eventNotifier"
+	EVENTFIELDS   string = "eventFields"
 )
 
 func getNodeID(attrs map[string]any, id string) (*ua.NodeID, error) {
@@ -27,3 +54,49 @@ func getNodeID(attrs map[string]any, id string) (*ua.NodeID, error) {
 
 	return ua.ParseNodeID(identifier.(string))
 }
+
+// loadCertificate reads a PEM or DER encoded X.509 certificate and returns its DER bytes
+func loadCertificate(filename string) ([]byte, error) {
+	b, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load certificate: %v", err)
+	}
+
+	if block, _ := pem.Decode(b); block != nil {
+		if block.Type != "CERTIFICATE" {
+			return nil, fmt.Errorf("failed to decode PEM block with certificate: unexpected type %s", block.Type)
+		}
+		b = block.Bytes
+	}
+
+	if _, err := x509.ParseCertificate(b); err != nil {
+		return nil, fmt.Errorf("failed to parse certificate: %v", err)
+	}
+	return b, nil
+}
+
+// loadPrivateKey reads a PEM or DER encoded RSA private key in PKCS#1 or PKCS#8 format
+func loadPrivateKey(filename string) (*rsa.PrivateKey, error) {
+	b, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load private key: %v", err)
+	}
+
+	if block, _ := pem.Decode(b); block != nil {
+		b = block.Bytes
+	}
+
+	if key, err := x509.ParsePKCS1PrivateKey(b); err == nil {
+		return key, nil
+	}
+
+	key, err := x509.ParsePKCS8PrivateKey(b)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse private key: %v", err)
+	}
+	rsaKey, ok := key.(*rsa.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("failed to parse private key: not an RSA key")
+	}
+	return rsaKey, nil
+}
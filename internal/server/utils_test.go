@@ -6,7 +6,13 @@
 
 package server
 
-import "testing"
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/edgexfoundry/device-opcua-go/internal/test"
+)
 
 func Test_getNodeID(t *testing.T) {
 	type args struct {
@@ -45,3 +51,69 @@ func Test_getNodeID(t *testing.T) {
 		})
 	}
 }
+
+func Test_loadCertificate(t *testing.T) {
+	dir := t.TempDir()
+	certFile, keyFile := test.CreateCertificate(t, dir, time.Now().Add(time.Hour))
+
+	tests := []struct {
+		name     string
+		filename string
+		wantErr  bool
+	}{
+		{
+			name:     "NOK - file does not exist",
+			filename: filepath.Join(dir, "missing.pem"),
+			wantErr:  true,
+		},
+		{
+			name:     "NOK - not a certificate",
+			filename: keyFile,
+			wantErr:  true,
+		},
+		{
+			name:     "OK - PEM certificate",
+			filename: certFile,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := loadCertificate(tt.filename); (err != nil) != tt.wantErr {
+				t.Errorf("loadCertificate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_loadPrivateKey(t *testing.T) {
+	dir := t.TempDir()
+	certFile, keyFile := test.CreateCertificate(t, dir, time.Now().Add(time.Hour))
+
+	tests := []struct {
+		name     string
+		filename string
+		wantErr  bool
+	}{
+		{
+			name:     "NOK - file does not exist",
+			filename: filepath.Join(dir, "missing.pem"),
+			wantErr:  true,
+		},
+		{
+			name:     "NOK - not a private key",
+			filename: certFile,
+			wantErr:  true,
+		},
+		{
+			name:     "OK - PKCS#1 PEM key",
+			filename: keyFile,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := loadPrivateKey(tt.filename); (err != nil) != tt.wantErr {
+				t.Errorf("loadPrivateKey() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
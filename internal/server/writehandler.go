@@ -13,7 +13,6 @@ import (
 
 	"github.com/edgexfoundry/device-opcua-go/pkg/command"
 	sdkModel "github.com/edgexfoundry/device-sdk-go/v4/pkg/models"
-	"github.com/gopcua/opcua"
 	"github.com/gopcua/opcua/ua"
 )
 
@@ -37,10 +36,18 @@ func (s *Server) handleWriteCommandRequest(req sdkModel.CommandRequest,
 		return fmt.Errorf("Driver.handleWriteCommands: invalid node id: %v", err)
 	}
 
+	numericRange, single, err := indexRange(req)
+	if err != nil {
+		return fmt.Errorf("Driver.handleWriteCommands: resource %s: %v", req.DeviceResourceName, err)
+	}
+
 	value, err := command.NewValue(req.Type, param)
 	if err != nil {
 		return err
 	}
+	if single {
+		value = elementArray(numericRange, value)
+	}
 
 	v, err := ua.NewVariant(value)
 	if err != nil {
@@ -52,6 +59,7 @@ func (s *Server) handleWriteCommandRequest(req sdkModel.CommandRequest,
 			{
 				NodeID:      id,
 				AttributeID: ua.AttributeIDValue,
+				IndexRange:  numericRange,
 				Value: &ua.DataValue{
 					EncodingMask: ua.DataValueValue, // encoding mask
 					Value:        v,
@@ -60,17 +68,26 @@ func (s *Server) handleWriteCommandRequest(req sdkModel.CommandRequest,
 		},
 	}
 
-	if s.client == nil || s.client.State() == opcua.Closed || s.client.State() == opcua.Disconnected {
-		if err := s.Connect(); err != nil {
-			return fmt.Errorf("Driver.handleWriteCommands: client not initialized: %s", err)
-		}
+	client, err := s.connectedClient()
+	if err != nil {
+		return fmt.Errorf("Driver.handleWriteCommands: client not initialized: %s", err)
 	}
 
-	resp, err := s.client.Write(s.client.ctx, request)
+	ctx, cancel := s.requestContext(client)
+	defer cancel()
+
+	resp, err := client.Write(ctx, request)
 	if err != nil {
 		s.sdk.LoggingClient().Errorf("Driver.handleWriteCommands: Write value %v failed: %s", v, err)
 		return err
 	}
+	// the server rejects a value, or its index range, with the status code of the node written
+	if len(resp.Results) == 0 {
+		return fmt.Errorf("Driver.handleWriteCommands: resource %s: no write result", req.DeviceResourceName)
+	}
+	if resp.Results[0] != ua.StatusOK {
+		return fmt.Errorf("Driver.handleWriteCommands: resource %s: write rejected: %w", req.DeviceResourceName, resp.Results[0])
+	}
 	s.sdk.LoggingClient().Infof("Driver.handleWriteCommands: write sucessfully, %v", resp.Results[0])
 	return nil
 }
@@ -7,6 +7,7 @@
 package server
 
 import (
+	"errors"
 	"fmt"
 	"testing"
 
@@ -33,7 +34,7 @@ func TestDriver_ProcessWriteCommands(t *testing.T) {
 		reqs := []sdkModel.CommandRequest{{DeviceResourceName: "TestVar1"}}
 		params := []*sdkModel.CommandValue{{}}
 		dsMock := test.NewDSMock(t)
-		s := NewServer("Test", dsMock)
+		s := NewServer("Test", dsMock, nil)
 		s.config = &Config{Endpoint: ""}
 
 		if err := s.ProcessWriteCommands(reqs, params); err == nil {
@@ -53,7 +54,7 @@ func TestDriver_ProcessWriteCommands(t *testing.T) {
 			Value:              int32(42),
 		}}
 		dsMock := test.NewDSMock(t)
-		s := NewServer("Test", dsMock)
+		s := NewServer("Test", dsMock, nil)
 		s.config = &Config{Endpoint: test.Address}
 
 		if err := s.ProcessWriteCommands(reqs, params); err == nil {
@@ -73,7 +74,7 @@ func TestDriver_ProcessWriteCommands(t *testing.T) {
 			Value:              "foobar",
 		}}
 		dsMock := test.NewDSMock(t)
-		s := NewServer("Test", dsMock)
+		s := NewServer("Test", dsMock, nil)
 		s.config = &Config{Endpoint: test.Address}
 
 		if err := s.ProcessWriteCommands(reqs, params); err == nil {
@@ -95,7 +96,7 @@ func TestDriver_ProcessWriteCommands(t *testing.T) {
 		dsMock := test.NewDSMock(t)
 		dsMock.On("GetDeviceByName", "Test").Return(models.Device{Protocols: map[string]models.ProtocolProperties{Protocol: {Endpoint: test.Address}}}, nil)
 
-		s := NewServer("Test", dsMock)
+		s := NewServer("Test", dsMock, nil)
 		clientMock := gopcuaMocks.NewMockClient(t)
 		s.client = &Client{clientMock, s.context.ctx}
 
@@ -111,6 +112,35 @@ func TestDriver_ProcessWriteCommands(t *testing.T) {
 		}
 	})
 
+	t.Run("NOK - index range rejected by the server", func(t *testing.T) {
+		reqs := []sdkModel.CommandRequest{{
+			DeviceResourceName: "TestResource1",
+			Attributes:         map[string]any{NODE: "ns=2;s=rw_int32_array", INDEXRANGE: "8"},
+			Type:               common.ValueTypeInt32,
+		}}
+		params := []*sdkModel.CommandValue{{
+			DeviceResourceName: "TestResource1",
+			Type:               common.ValueTypeInt32,
+			Value:              int32(42),
+		}}
+
+		dsMock := test.NewDSMock(t)
+		s := NewServer("Test", dsMock, nil)
+
+		clientMock := gopcuaMocks.NewMockClient(t)
+		s.client = &Client{clientMock, s.context.ctx}
+
+		clientMock.On("State").Return(opcua.Connected)
+		clientMock.On("Write", test.RequestContext(), mock.MatchedBy(func(r *ua.WriteRequest) bool {
+			return r.NodesToWrite[0].IndexRange == "8"
+		})).Return(&ua.WriteResponse{Results: []ua.StatusCode{ua.StatusBadIndexRangeNoData}}, nil)
+
+		err := s.ProcessWriteCommands(reqs, params)
+		if !errors.Is(err, ua.StatusBadIndexRangeNoData) {
+			t.Errorf("Driver.HandleWriteCommands() error = %v, want %v", err, ua.StatusBadIndexRangeNoData)
+		}
+	})
+
 	t.Run("OK - command request with one parameter", func(t *testing.T) {
 		reqs := []sdkModel.CommandRequest{{
 			DeviceResourceName: "TestResource1",
@@ -124,13 +154,13 @@ func TestDriver_ProcessWriteCommands(t *testing.T) {
 		}}
 
 		dsMock := test.NewDSMock(t)
-		s := NewServer("Test", dsMock)
+		s := NewServer("Test", dsMock, nil)
 
 		clientMock := gopcuaMocks.NewMockClient(t)
 		s.client = &Client{clientMock, s.context.ctx}
 
 		clientMock.On("State").Return(opcua.Connected)
-		clientMock.On("Write", s.context.ctx, mock.Anything).Return(&ua.WriteResponse{Results: []ua.StatusCode{ua.StatusOK}}, nil)
+		clientMock.On("Write", test.RequestContext(), mock.Anything).Return(&ua.WriteResponse{Results: []ua.StatusCode{ua.StatusOK}}, nil)
 
 		gopcua.NewClient = func(endpoint string, opts ...opcua.Option) (gopcua.Client, error) {
 			return clientMock, nil
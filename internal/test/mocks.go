@@ -54,3 +54,11 @@ func MockGetEndpoints() {
 		return nil, fmt.Errorf("bad endpoint")
 	}
 }
+
+// RequestContext matches the context of a request bounded by a deadline
+func RequestContext() any {
+	return mock.MatchedBy(func(ctx context.Context) bool {
+		_, ok := ctx.Deadline()
+		return ok
+	})
+}
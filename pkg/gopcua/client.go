@@ -10,6 +10,7 @@ import (
 
 	"github.com/gopcua/opcua"
 	"github.com/gopcua/opcua/ua"
+	"github.com/gopcua/opcua/uasc"
 )
 
 // Client is an interface for opcua.Client to allow for mocking
@@ -21,6 +22,11 @@ type Client interface {
 	Write(ctx context.Context, req *ua.WriteRequest) (*ua.WriteResponse, error)
 	Subscribe(ctx context.Context, params *opcua.SubscriptionParameters, notifyCh chan<- *opcua.PublishNotificationData) (*opcua.Subscription, error)
 	State() opcua.ConnState
+	CreateSession(ctx context.Context, cfg *uasc.SessionConfig) (*opcua.Session, error)
+	ActivateSession(ctx context.Context, s *opcua.Session) error
+	DetachSession(ctx context.Context) (*opcua.Session, error)
+	SubscriptionIDs() []uint32
+	Send(ctx context.Context, req ua.Request, h func(ua.Response) error) error
 }
 
 // NewClient returns a real OPC UA client, but can be overwritten for testing
@@ -150,6 +150,11 @@ func NewResult(req sdkModel.CommandRequest, reading any) (*sdkModel.CommandValue
 		if err != nil {
 			return nil, fmt.Errorf(castError, req.DeviceResourceName, err)
 		}
+	case common.ValueTypeObject:
+		val, err = cast.ToStringMapE(reading)
+		if err != nil {
+			return nil, fmt.Errorf(castError, req.DeviceResourceName, err)
+		}
 	default:
 		err = fmt.Errorf("return result fail, none supported value type: %v", req.Type)
 		return nil, err
@@ -766,3 +766,18 @@ func TestNewResult_float64Array(t *testing.T) {
 	assert.Equal(t, val, []float64{1.1, 2.2, 3.3})
 	assert.NoError(t, err)
 }
+
+func TestNewResult_object(t *testing.T) {
+	var reading any = map[string]any{"Severity": uint16(500), "SourceName": "Boiler"}
+	req := models.CommandRequest{
+		DeviceResourceName: "alarm",
+		Type:               common.ValueTypeObject,
+	}
+
+	cmdVal, err := NewResult(req, reading)
+	require.NoError(t, err)
+
+	val, err := cmdVal.ObjectValue()
+	assert.Equal(t, reading, val)
+	assert.NoError(t, err)
+}
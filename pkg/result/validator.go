@@ -21,7 +21,8 @@ func checkValueInRange(valueType string, reading any) bool {
 	isValid := false
 
 	if valueType == common.ValueTypeString || valueType == common.ValueTypeBool ||
-		valueType == common.ValueTypeBoolArray || valueType == common.ValueTypeStringArray {
+		valueType == common.ValueTypeBoolArray || valueType == common.ValueTypeStringArray ||
+		valueType == common.ValueTypeObject {
 		return true
 	}
 